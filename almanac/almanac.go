@@ -0,0 +1,246 @@
+// Package almanac models the Island Island Almanac used by stars nine and ten.
+// See: https://adventofcode.com/2023/day/5
+package almanac
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cfunkhouser/aoc2023/interval"
+	"github.com/cfunkhouser/aoc2023/util"
+)
+
+// Range of a Map, which maps each value in [Source, Source+Len) to the value
+// at the same offset from Dest.
+type Range struct {
+	Dest, Source, Len int
+}
+
+// Interval of source values mapped by the range.
+func (r Range) Interval() interval.Interval {
+	return interval.Of(r.Source, r.Len)
+}
+
+// Map from one category of values to another, such as seeds to soil. Values
+// outside of every range map to themselves.
+type Map struct {
+	From, To string
+	// Ranges of the map, sorted by Source.
+	Ranges []Range
+}
+
+// Name of the map, as written in the almanac.
+func (m *Map) Name() string {
+	return m.From + "-to-" + m.To
+}
+
+// Lookup the value to which v maps.
+func (m *Map) Lookup(v int) int {
+	i := sort.Search(len(m.Ranges), func(i int) bool { return m.Ranges[i].Source+m.Ranges[i].Len > v })
+	if i < len(m.Ranges) && m.Ranges[i].Interval().Contains(v) {
+		return v - m.Ranges[i].Source + m.Ranges[i].Dest
+	}
+	return v
+}
+
+// Piece of an interval, and the interval to which it maps.
+type Piece struct {
+	From, To interval.Interval
+}
+
+// Split an interval of values into pieces by the ranges of the map, and map
+// each of them. The pieces are in order of their source values.
+func (m *Map) Split(iv interval.Interval) (ret []Piece) {
+	rest := iv
+	for _, r := range m.Ranges {
+		before, inside, after := rest.Split(r.Interval())
+		if !before.Empty() {
+			ret = append(ret, Piece{before, before})
+		}
+		if !inside.Empty() {
+			ret = append(ret, Piece{inside, inside.Shift(r.Dest - r.Source)})
+		}
+		if rest = after; rest.Empty() {
+			return
+		}
+	}
+	return append(ret, Piece{rest, rest})
+}
+
+// Apply the map to every value in a set.
+func (m *Map) Apply(s *interval.Set) *interval.Set {
+	ret := &interval.Set{}
+	for _, iv := range s.Intervals() {
+		for _, p := range m.Split(iv) {
+			ret.Add(p.To)
+		}
+	}
+	return ret
+}
+
+// Almanac of seeds to be planted, and the maps from seeds through each
+// category to locations.
+type Almanac struct {
+	Seeds []int
+	// Maps in the order they are applied.
+	Maps []*Map
+}
+
+// SeedIntervals of length one, one per seed, in the order they are listed.
+func (a *Almanac) SeedIntervals() []interval.Interval {
+	ret := make([]interval.Interval, len(a.Seeds))
+	for i, s := range a.Seeds {
+		ret[i] = interval.Of(s, 1)
+	}
+	return ret
+}
+
+// ErrInvalidAlmanac is returned when an almanac cannot be parsed.
+var ErrInvalidAlmanac = errors.New("invalid almanac")
+
+// SeedRanges interprets the seeds as pairs of the start and length of each
+// range of seeds.
+func (a *Almanac) SeedRanges() (*interval.Set, error) {
+	if len(a.Seeds)%2 != 0 {
+		return nil, fmt.Errorf("%w: %d seed values do not form pairs", ErrInvalidAlmanac, len(a.Seeds))
+	}
+	ret := &interval.Set{}
+	for i := 0; i < len(a.Seeds); i += 2 {
+		ret.Add(interval.Of(a.Seeds[i], a.Seeds[i+1]))
+	}
+	return ret, nil
+}
+
+// Location of a seed, once mapped through every map.
+func (a *Almanac) Location(seed int) int {
+	for _, m := range a.Maps {
+		seed = m.Lookup(seed)
+	}
+	return seed
+}
+
+// Locations of a set of seeds, once mapped through every map.
+func (a *Almanac) Locations(seeds *interval.Set) *interval.Set {
+	for _, m := range a.Maps {
+		seeds = m.Apply(seeds)
+	}
+	return seeds
+}
+
+// ErrNoSeeds is returned when the lowest location of no seeds is requested.
+var ErrNoSeeds = errors.New("no seeds")
+
+// LowestLocation of any of a set of seeds.
+func (a *Almanac) LowestLocation(seeds *interval.Set) (int, error) {
+	v, ok := a.Locations(seeds).Min()
+	if !ok {
+		return 0, ErrNoSeeds
+	}
+	return v, nil
+}
+
+func formatInterval(iv interval.Interval) string {
+	if iv.Len() == 1 {
+		return fmt.Sprint(iv.Start)
+	}
+	return iv.String()
+}
+
+// WriteTrace writes an account of intervals of seeds passing through each map
+// to w, showing how each interval of values is split and where each piece
+// lands. The pieces are followed separately through the following maps, even
+// where they meet or overlap.
+func (a *Almanac) WriteTrace(w io.Writer, seeds []interval.Interval) error {
+	var buf strings.Builder
+	var parts []string
+	for _, iv := range seeds {
+		parts = append(parts, formatInterval(iv))
+	}
+	fmt.Fprintf(&buf, "seeds: %s\n", strings.Join(parts, " "))
+	for _, m := range a.Maps {
+		fmt.Fprintf(&buf, "%s:\n", m.Name())
+		var next []interval.Interval
+		for _, iv := range seeds {
+			parts = parts[:0]
+			for _, p := range m.Split(iv) {
+				parts = append(parts, formatInterval(p.To))
+				next = append(next, p.To)
+			}
+			fmt.Fprintf(&buf, "  %s -> %s\n", formatInterval(iv), strings.Join(parts, " "))
+		}
+		seeds = next
+	}
+	if v, ok := interval.NewSet(seeds...).Min(); ok {
+		fmt.Fprintf(&buf, "lowest location: %d\n", v)
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// parseMapHeader parses a line of the form "seed-to-soil map:".
+func parseMapHeader(s string) (*Map, bool) {
+	name, ok := strings.CutSuffix(s, " map:")
+	if !ok {
+		return nil, false
+	}
+	from, to, ok := strings.Cut(name, "-to-")
+	if !ok || from == "" || to == "" {
+		return nil, false
+	}
+	return &Map{From: from, To: to}, true
+}
+
+// FromDocument parses an almanac: a line of seeds, followed by maps each
+// consisting of a header and a range per line, separated by blank lines. Each
+// map must begin with the category at which the previous one ended.
+func FromDocument(doc io.Reader) (*Almanac, error) {
+	a := &Almanac{}
+	var m *Map
+	s := bufio.NewScanner(doc)
+	for line := 1; s.Scan(); line++ {
+		l := strings.TrimSpace(s.Text())
+		var err error
+		switch {
+		case line == 1:
+			seeds, ok := strings.CutPrefix(l, "seeds:")
+			if !ok {
+				err = errors.New("missing seeds")
+			} else {
+				a.Seeds, err = util.FieldInts(seeds)
+			}
+		case l == "":
+			m = nil
+		case m == nil:
+			var ok bool
+			if m, ok = parseMapHeader(l); !ok {
+				err = fmt.Errorf("expected map header, got %q", l)
+			} else if n := len(a.Maps); n > 0 && a.Maps[n-1].To != m.From {
+				err = fmt.Errorf("%s map does not follow %s map", m.Name(), a.Maps[n-1].Name())
+			} else {
+				a.Maps = append(a.Maps, m)
+			}
+		default:
+			var vs []int
+			if vs, err = util.FieldInts(l); err == nil && len(vs) != 3 {
+				err = fmt.Errorf("expected 3 values in range, got %d", len(vs))
+			}
+			if err == nil {
+				m.Ranges = append(m.Ranges, Range{Dest: vs[0], Source: vs[1], Len: vs[2]})
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w: %w", line, ErrInvalidAlmanac, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	for _, m := range a.Maps {
+		sort.Slice(m.Ranges, func(i, j int) bool { return m.Ranges[i].Source < m.Ranges[j].Source })
+	}
+	return a, nil
+}
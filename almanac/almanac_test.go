@@ -0,0 +1,167 @@
+package almanac
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cfunkhouser/aoc2023/interval"
+	"github.com/google/go-cmp/cmp"
+)
+
+const example = `seeds: 79 14 55 13
+
+seed-to-soil map:
+50 98 2
+52 50 48
+
+soil-to-fertilizer map:
+0 15 37
+37 52 2
+39 0 15
+
+fertilizer-to-water map:
+49 53 8
+0 11 42
+42 0 7
+57 7 4
+
+water-to-light map:
+88 18 7
+18 25 70
+
+light-to-temperature map:
+45 77 23
+81 45 19
+68 64 13
+
+temperature-to-humidity map:
+0 69 1
+1 0 69
+
+humidity-to-location map:
+60 56 37
+56 93 4`
+
+func exampleAlmanac(t *testing.T) *Almanac {
+	t.Helper()
+	a, err := FromDocument(bytes.NewBufferString(example))
+	if err != nil {
+		t.Fatalf("FromDocument(): unexpected error: %v", err)
+	}
+	return a
+}
+
+func TestFromDocument(t *testing.T) {
+	a := exampleAlmanac(t)
+	if diff := cmp.Diff(a.Seeds, []int{79, 14, 55, 13}); diff != "" {
+		t.Errorf("Seeds: mismatch (-got,+want):\n%v", diff)
+	}
+	if len(a.Maps) != 7 || a.Maps[0].Name() != "seed-to-soil" || a.Maps[6].To != "location" {
+		t.Errorf("Maps: got: %v", a.Maps)
+	}
+	// Ranges are sorted by source.
+	if diff := cmp.Diff(a.Maps[0].Ranges, []Range{{52, 50, 48}, {50, 98, 2}}); diff != "" {
+		t.Errorf("Ranges: mismatch (-got,+want):\n%v", diff)
+	}
+}
+
+func TestFromDocumentErrors(t *testing.T) {
+	for tn, doc := range map[string]string{
+		"missing seeds":  "79 14\n",
+		"bad seed":       "seeds: 79 x\n",
+		"missing header": "seeds: 1\n\n50 98 2\n",
+		"short range":    "seeds: 1\n\nseed-to-soil map:\n50 98\n",
+		"broken chain":   "seeds: 1\n\nseed-to-soil map:\n50 98 2\n\nwater-to-light map:\n1 2 3\n",
+	} {
+		func(t *testing.T, tn, doc string) {
+			t.Run(tn, func(t *testing.T) {
+				if _, err := FromDocument(bytes.NewBufferString(doc)); !errors.Is(err, ErrInvalidAlmanac) {
+					t.Errorf("FromDocument(): got error: %v want: %v", err, ErrInvalidAlmanac)
+				}
+			})
+		}(t, tn, doc)
+	}
+}
+
+func TestLocation(t *testing.T) {
+	a := exampleAlmanac(t)
+	for seed, want := range map[int]int{79: 82, 14: 43, 55: 86, 13: 35} {
+		if got := a.Location(seed); got != want {
+			t.Errorf("Location(%d): got: %d want: %d", seed, got, want)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	m := &Map{Ranges: []Range{{100, 10, 5}, {200, 20, 5}}}
+	got := m.Split(interval.Interval{Start: 8, End: 22})
+	want := []Piece{
+		{interval.Interval{Start: 8, End: 10}, interval.Interval{Start: 8, End: 10}},
+		{interval.Interval{Start: 10, End: 15}, interval.Interval{Start: 100, End: 105}},
+		{interval.Interval{Start: 15, End: 20}, interval.Interval{Start: 15, End: 20}},
+		{interval.Interval{Start: 20, End: 22}, interval.Interval{Start: 200, End: 202}},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Split(): mismatch (-got,+want):\n%v", diff)
+	}
+}
+
+func TestLowestLocation(t *testing.T) {
+	a := exampleAlmanac(t)
+	if got, err := a.LowestLocation(interval.NewSet(a.SeedIntervals()...)); err != nil || got != 35 {
+		t.Errorf("LowestLocation(seeds): got: %d, %v want: 35", got, err)
+	}
+	ranges, err := a.SeedRanges()
+	if err != nil {
+		t.Fatalf("SeedRanges(): unexpected error: %v", err)
+	}
+	if got, err := a.LowestLocation(ranges); err != nil || got != 46 {
+		t.Errorf("LowestLocation(ranges): got: %d, %v want: 46", got, err)
+	}
+
+	// Mapping the ranges as a whole agrees with mapping each seed.
+	want := &interval.Set{}
+	for _, iv := range ranges.Intervals() {
+		for s := iv.Start; s < iv.End; s++ {
+			want.Add(interval.Of(a.Location(s), 1))
+		}
+	}
+	if diff := cmp.Diff(a.Locations(ranges).Intervals(), want.Intervals()); diff != "" {
+		t.Errorf("Locations(ranges): mismatch (-got,+want):\n%v", diff)
+	}
+
+	if _, err := (&Almanac{Seeds: []int{1}}).SeedRanges(); !errors.Is(err, ErrInvalidAlmanac) {
+		t.Errorf("SeedRanges() of odd seeds: got error: %v want: %v", err, ErrInvalidAlmanac)
+	}
+	if _, err := a.LowestLocation(&interval.Set{}); !errors.Is(err, ErrNoSeeds) {
+		t.Errorf("LowestLocation() of no seeds: got error: %v want: %v", err, ErrNoSeeds)
+	}
+}
+
+func TestWriteTrace(t *testing.T) {
+	a := exampleAlmanac(t)
+	ranges, err := a.SeedRanges()
+	if err != nil {
+		t.Fatalf("SeedRanges(): unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := a.WriteTrace(&buf, ranges.Intervals()); err != nil {
+		t.Fatalf("WriteTrace(): unexpected error: %v", err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	want := []string{
+		"seeds: [55,68) [79,93)",
+		"seed-to-soil:",
+		"  [55,68) -> [57,70)",
+		"  [79,93) -> [81,95)",
+		"soil-to-fertilizer:",
+	}
+	if diff := cmp.Diff(lines[:len(want)], want); diff != "" {
+		t.Errorf("WriteTrace(): mismatch (-got,+want):\n%v", diff)
+	}
+	if got := lines[len(lines)-2]; got != "lowest location: 46" {
+		t.Errorf("WriteTrace(): last line: got: %q", got)
+	}
+}
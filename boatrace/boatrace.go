@@ -0,0 +1,171 @@
+// Package boatrace models the toy boat races used by stars eleven and twelve.
+// See: https://adventofcode.com/2023/day/6
+package boatrace
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/cfunkhouser/aoc2023/util"
+)
+
+// Race lasting Time milliseconds, in which the record distance is Record
+// millimetres. Holding the button for h milliseconds moves the boat
+// h * (Time - h) millimetres.
+type Race struct {
+	Time, Record int
+}
+
+// beats is true if holding the button for h milliseconds beats the record.
+func (r Race) beats(h int) bool {
+	d, ok := util.MulChecked(h, r.Time-h)
+	// Both factors are non-negative when h lies within the race, so an
+	// overflowing distance exceeds any record.
+	return !ok || d > r.Record
+}
+
+// discriminantRoot is the integer square root of Time^2 - 4*Record, the
+// discriminant of h^2 - Time*h + Record, or false if it is negative. The
+// discriminant is computed exactly, with arbitrary precision only if it does
+// not fit in an int.
+func (r Race) discriminantRoot() (int, bool) {
+	tt, ok1 := util.MulChecked(r.Time, r.Time)
+	fd, ok2 := util.MulChecked(4, r.Record)
+	// Negating the most negative int overflows.
+	disc, ok3 := util.AddChecked(tt, -fd)
+	if ok1 && ok2 && ok3 && fd != math.MinInt {
+		if disc < 0 {
+			return 0, false
+		}
+		return util.ISqrt(disc), true
+	}
+	bd := new(big.Int).Mul(big.NewInt(int64(r.Time)), big.NewInt(int64(r.Time)))
+	bd.Sub(bd, new(big.Int).Mul(big.NewInt(4), big.NewInt(int64(r.Record))))
+	if bd.Sign() < 0 {
+		return 0, false
+	}
+	// The root is at most Time, so fits in an int.
+	return int(bd.Sqrt(bd).Int64()), true
+}
+
+// Ways to beat the record: the number of whole milliseconds for which the
+// button can be held to travel further than Record. These lie strictly between
+// the roots of h^2 - Time*h + Record, which are found exactly with an integer
+// square root rather than by trying every hold time. A race with a negative
+// time or record is invalid, and has no ways to win; otherwise every hold time
+// could beat a record of -1, and there are more of those than an int can count
+// when Time is math.MaxInt.
+func (r Race) Ways() int {
+	if r.validate() != nil {
+		return 0
+	}
+	s, ok := r.discriminantRoot()
+	if !ok {
+		return 0
+	}
+	// The floor of the lower root is within one of (Time - s) / 2. Step to the
+	// first hold time which beats the record, which excludes the root itself
+	// when it is a whole number, as equalling the record does not beat it.
+	lo := max(0, (r.Time-s)/2)
+	for lo > 0 && r.beats(lo-1) {
+		lo--
+	}
+	for lo <= r.Time/2 && !r.beats(lo) {
+		lo++
+	}
+	if lo > r.Time/2 {
+		return 0
+	}
+	// Distances are symmetric about Time / 2.
+	return r.Time - 2*lo + 1
+}
+
+// ErrInvalidRaces is returned when a document of races cannot be parsed, or
+// holds a negative time or record.
+var ErrInvalidRaces = errors.New("invalid races")
+
+func (r Race) validate() error {
+	if r.Time < 0 || r.Record < 0 {
+		return fmt.Errorf("%w: negative time or record in %+v", ErrInvalidRaces, r)
+	}
+	return nil
+}
+
+// readLines reads the values following the Time: and Distance: labels.
+func readLines(doc io.Reader) (times, records string, err error) {
+	s := bufio.NewScanner(doc)
+	var lines []string
+	for s.Scan() {
+		if l := strings.TrimSpace(s.Text()); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return "", "", err
+	}
+	if len(lines) != 2 {
+		return "", "", fmt.Errorf("%w: expected 2 lines, got %d", ErrInvalidRaces, len(lines))
+	}
+	times, ok1 := strings.CutPrefix(lines[0], "Time:")
+	records, ok2 := strings.CutPrefix(lines[1], "Distance:")
+	if !ok1 || !ok2 {
+		return "", "", fmt.Errorf("%w: expected Time: and Distance: lines", ErrInvalidRaces)
+	}
+	return times, records, nil
+}
+
+// FromDocument parses a list of races from a document of the form:
+//
+//	Time:      7  15   30
+//	Distance:  9  40  200
+func FromDocument(doc io.Reader) ([]Race, error) {
+	times, records, err := readLines(doc)
+	if err != nil {
+		return nil, err
+	}
+	ts, err := util.FieldInts(times)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRaces, err)
+	}
+	rs, err := util.FieldInts(records)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRaces, err)
+	}
+	if len(ts) != len(rs) {
+		return nil, fmt.Errorf("%w: %d times but %d distances", ErrInvalidRaces, len(ts), len(rs))
+	}
+	ret := make([]Race, len(ts))
+	for i := range ts {
+		ret[i] = Race{Time: ts[i], Record: rs[i]}
+		if err := ret[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// KernedFromDocument parses a single race from a document in the same form as
+// FromDocument, ignoring the spaces between the digits of each value.
+func KernedFromDocument(doc io.Reader) (Race, error) {
+	times, records, err := readLines(doc)
+	if err != nil {
+		return Race{}, err
+	}
+	var vs [2]int
+	for i, s := range []string{times, records} {
+		if vs[i], err = strconv.Atoi(strings.Join(strings.Fields(s), "")); err != nil {
+			return Race{}, fmt.Errorf("%w: %w", ErrInvalidRaces, err)
+		}
+	}
+	r := Race{Time: vs[0], Record: vs[1]}
+	if err := r.validate(); err != nil {
+		return Race{}, err
+	}
+	return r, nil
+}
@@ -0,0 +1,112 @@
+package boatrace
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const example = `Time:      7  15   30
+Distance:  9  40  200`
+
+func TestWays(t *testing.T) {
+	type test struct {
+		race Race
+		want int
+	}
+
+	for tn, tc := range map[string]test{
+		"example 1": {Race{7, 9}, 4},
+		"example 2": {Race{15, 40}, 8},
+		// The roots are exactly 10 and 20, which only equal the record.
+		"example 3":           {Race{30, 200}, 9},
+		"kerned example":      {Race{71530, 940200}, 71503},
+		"single exact root":   {Race{4, 4}, 0},
+		"single winning hold": {Race{4, 3}, 1},
+		"unbeatable":          {Race{4, 5}, 0},
+		"zero record":         {Race{5, 0}, 4},
+		"no time":             {Race{0, 0}, 0},
+		// Time^2 overflows an int, so the discriminant needs big arithmetic.
+		// The roots are exactly 1e9 and 3e9.
+		"large exact roots": {Race{4_000_000_000, 3_000_000_000_000_000_000}, 1_999_999_999},
+		// Roots of 1 and MaxInt64-1, between which distances overflow.
+		"near int64 range": {Race{math.MaxInt64, math.MaxInt64 - 1}, math.MaxInt64 - 3},
+		// Invalid, as every hold would beat it, one more than an int can count.
+		"negative record": {Race{math.MaxInt, -1}, 0},
+		"negative time":   {Race{-1, 0}, 0},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				if got := tc.race.Ways(); got != tc.want {
+					t.Errorf("%+v.Ways(): got: %d want: %d", tc.race, got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestWaysExhaustive(t *testing.T) {
+	for time := 0; time < 40; time++ {
+		for record := -2; record <= time*time/4+1; record++ {
+			// Negative records are invalid, so cannot be beaten.
+			var want int
+			for h := 0; h <= time && record >= 0; h++ {
+				if h*(time-h) > record {
+					want++
+				}
+			}
+			r := Race{time, record}
+			if got := r.Ways(); got != want {
+				t.Errorf("%+v.Ways(): got: %d want: %d", r, got, want)
+			}
+		}
+	}
+}
+
+func TestFromDocument(t *testing.T) {
+	got, err := FromDocument(bytes.NewBufferString(example))
+	if err != nil {
+		t.Fatalf("FromDocument(): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(got, []Race{{7, 9}, {15, 40}, {30, 200}}); diff != "" {
+		t.Errorf("FromDocument(): mismatch (-got,+want):\n%v", diff)
+	}
+
+	race, err := KernedFromDocument(bytes.NewBufferString(example))
+	if err != nil {
+		t.Fatalf("KernedFromDocument(): unexpected error: %v", err)
+	}
+	if race != (Race{71530, 940200}) {
+		t.Errorf("KernedFromDocument(): got: %+v want: {71530 940200}", race)
+	}
+}
+
+func TestFromDocumentErrors(t *testing.T) {
+	for tn, doc := range map[string]string{
+		"empty":             "",
+		"missing distances": "Time: 7 15",
+		"mismatched":        "Time: 7 15\nDistance: 9",
+		"unlabelled":        "7 15\n9 40",
+		"invalid":           "Time: 7 x\nDistance: 9 40",
+		"negative record":   "Time: 7 15\nDistance: 9 -1",
+	} {
+		func(t *testing.T, tn, doc string) {
+			t.Run(tn, func(t *testing.T) {
+				if _, err := FromDocument(bytes.NewBufferString(doc)); !errors.Is(err, ErrInvalidRaces) {
+					t.Errorf("FromDocument(): got error: %v want: %v", err, ErrInvalidRaces)
+				}
+			})
+		}(t, tn, doc)
+	}
+	_, err := KernedFromDocument(bytes.NewBufferString("Time: 9223372036 854775808\nDistance: 1"))
+	if !errors.Is(err, ErrInvalidRaces) {
+		t.Errorf("KernedFromDocument() out of range: got error: %v want: %v", err, ErrInvalidRaces)
+	}
+	_, err = KernedFromDocument(bytes.NewBufferString("Time: 7 1\nDistance: -9 4"))
+	if !errors.Is(err, ErrInvalidRaces) {
+		t.Errorf("KernedFromDocument() negative record: got error: %v want: %v", err, ErrInvalidRaces)
+	}
+}
@@ -0,0 +1,232 @@
+// Package camelcards ranks the hands of Camel Cards played in stars thirteen
+// and fourteen.
+// See: https://adventofcode.com/2023/day/7
+package camelcards
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Kind of hand, from weakest to strongest.
+type Kind int
+
+const (
+	HighCard Kind = iota
+	OnePair
+	TwoPair
+	ThreeOfAKind
+	FullHouse
+	FourOfAKind
+	FiveOfAKind
+)
+
+var kindNames = [...]string{
+	HighCard:     "High card",
+	OnePair:      "One pair",
+	TwoPair:      "Two pair",
+	ThreeOfAKind: "Three of a kind",
+	FullHouse:    "Full house",
+	FourOfAKind:  "Four of a kind",
+	FiveOfAKind:  "Five of a kind",
+}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+// HandSize is the number of cards in a hand.
+const HandSize = 5
+
+// Hand of cards, and the bid placed on it.
+type Hand struct {
+	Cards string
+	Bid   int
+}
+
+// Rules by which hands are classified and compared.
+type Rules struct {
+	// Order of the cards, from weakest to strongest.
+	Order string
+	// Joker, if not 0, is a card which acts as whichever card makes the
+	// strongest kind of hand. It should be the weakest card in Order.
+	Joker rune
+}
+
+var (
+	// Standard rules, without jokers.
+	Standard = Rules{Order: "23456789TJQKA"}
+	// Jokers rules, in which J is a joker, and the weakest card.
+	Jokers = Rules{Order: "J23456789TQKA", Joker: 'J'}
+)
+
+// ErrInvalidHand is returned when a hand cannot be parsed or contains a card
+// unknown to the rules.
+var ErrInvalidHand = errors.New("invalid hand")
+
+// Validate that the hand has HandSize cards, all of which are in Order.
+func (r Rules) Validate(h Hand) error {
+	if n := len([]rune(h.Cards)); n != HandSize {
+		return fmt.Errorf("%w: %q has %d cards, want %d", ErrInvalidHand, h.Cards, n, HandSize)
+	}
+	for _, c := range h.Cards {
+		if !strings.ContainsRune(r.Order, c) {
+			return fmt.Errorf("%w: %q has unknown card %q", ErrInvalidHand, h.Cards, c)
+		}
+	}
+	return nil
+}
+
+// Classify the kind of a hand. Any jokers join the largest group of other
+// cards, which always makes the strongest kind.
+func (r Rules) Classify(h Hand) Kind {
+	counts := make(map[rune]int)
+	var jokers int
+	for _, c := range h.Cards {
+		if r.Joker != 0 && c == r.Joker {
+			jokers++
+		} else {
+			counts[c]++
+		}
+	}
+	groups := make([]int, 0, len(counts))
+	for _, n := range counts {
+		groups = append(groups, n)
+	}
+	slices.Sort(groups)
+	slices.Reverse(groups)
+	// Pad the groups so that short hands, or hands of only jokers, can be
+	// classified too.
+	for len(groups) < 2 {
+		groups = append(groups, 0)
+	}
+	groups[0] += jokers
+
+	switch {
+	case groups[0] >= 5:
+		return FiveOfAKind
+	case groups[0] == 4:
+		return FourOfAKind
+	case groups[0] == 3 && groups[1] == 2:
+		return FullHouse
+	case groups[0] == 3:
+		return ThreeOfAKind
+	case groups[0] == 2 && groups[1] == 2:
+		return TwoPair
+	case groups[0] == 2:
+		return OnePair
+	}
+	return HighCard
+}
+
+// strength of a card, its position in Order.
+func (r Rules) strength(c rune) int {
+	return strings.IndexRune(r.Order, c)
+}
+
+// Compare two hands, returning a negative number if a is weaker than b, a
+// positive number if it is stronger, and 0 if they are equal. Hands are ordered
+// first by kind, then by the strength of each card in turn.
+func (r Rules) Compare(a, b Hand) int {
+	if c := cmp.Compare(r.Classify(a), r.Classify(b)); c != 0 {
+		return c
+	}
+	return r.compareCards(a, b)
+}
+
+// compareCards compares two hands by the strength of each card in turn.
+func (r Rules) compareCards(a, b Hand) int {
+	ar, br := []rune(a.Cards), []rune(b.Cards)
+	for i := 0; i < len(ar) && i < len(br); i++ {
+		if c := cmp.Compare(r.strength(ar[i]), r.strength(br[i])); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(ar), len(br))
+}
+
+// Ranked hand.
+type Ranked struct {
+	Hand
+	Kind Kind
+	// Rank of the hand, from 1 for the weakest.
+	Rank int
+}
+
+// Winnings of the hand: its bid multiplied by its rank.
+func (r Ranked) Winnings() int {
+	return r.Bid * r.Rank
+}
+
+// Rank hands from weakest to strongest. Equal hands are ranked in the order
+// they were given.
+func (r Rules) Rank(hands []Hand) ([]Ranked, error) {
+	ret := make([]Ranked, len(hands))
+	for i, h := range hands {
+		if err := r.Validate(h); err != nil {
+			return nil, err
+		}
+		ret[i] = Ranked{Hand: h, Kind: r.Classify(h)}
+	}
+	slices.SortStableFunc(ret, func(a, b Ranked) int {
+		if c := cmp.Compare(a.Kind, b.Kind); c != 0 {
+			return c
+		}
+		return r.compareCards(a.Hand, b.Hand)
+	})
+	for i := range ret {
+		ret[i].Rank = i + 1
+	}
+	return ret, nil
+}
+
+// TotalWinnings of ranked hands.
+func TotalWinnings(ranked []Ranked) (total int) {
+	for _, r := range ranked {
+		total += r.Winnings()
+	}
+	return
+}
+
+// Parse a hand from a line of the form:
+//
+//	32T3K 765
+func Parse(s string) (Hand, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Hand{}, fmt.Errorf("%w: %q: expected cards and a bid", ErrInvalidHand, s)
+	}
+	bid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Hand{}, fmt.Errorf("%w: %w", ErrInvalidHand, err)
+	}
+	return Hand{Cards: fields[0], Bid: bid}, nil
+}
+
+// FromDocument parses hands, one per line.
+func FromDocument(doc io.Reader) (hands []Hand, err error) {
+	s := bufio.NewScanner(doc)
+	for line := 1; s.Scan(); line++ {
+		if strings.TrimSpace(s.Text()) == "" {
+			continue
+		}
+		h, err := Parse(s.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		hands = append(hands, h)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return
+}
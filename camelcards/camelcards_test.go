@@ -0,0 +1,148 @@
+package camelcards
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const example = `32T3K 765
+T55J5 684
+KK677 28
+KTJJT 220
+QQQJA 483`
+
+func exampleHands(t *testing.T) []Hand {
+	t.Helper()
+	hands, err := FromDocument(bytes.NewBufferString(example))
+	if err != nil {
+		t.Fatalf("FromDocument(): unexpected error: %v", err)
+	}
+	return hands
+}
+
+func TestClassify(t *testing.T) {
+	for cards, want := range map[string][2]Kind{
+		"AAAAA": {FiveOfAKind, FiveOfAKind},
+		"AA8AA": {FourOfAKind, FourOfAKind},
+		"23332": {FullHouse, FullHouse},
+		"TTT98": {ThreeOfAKind, ThreeOfAKind},
+		"23432": {TwoPair, TwoPair},
+		"A23A4": {OnePair, OnePair},
+		"23456": {HighCard, HighCard},
+		"T55J5": {ThreeOfAKind, FourOfAKind},
+		"KTJJT": {TwoPair, FourOfAKind},
+		"2345J": {HighCard, OnePair},
+		"22J33": {TwoPair, FullHouse},
+		"JJJJJ": {FiveOfAKind, FiveOfAKind},
+		"JJJJ2": {FourOfAKind, FiveOfAKind},
+	} {
+		h := Hand{Cards: cards}
+		if got := Standard.Classify(h); got != want[0] {
+			t.Errorf("Standard.Classify(%s): got: %v want: %v", cards, got, want[0])
+		}
+		if got := Jokers.Classify(h); got != want[1] {
+			t.Errorf("Jokers.Classify(%s): got: %v want: %v", cards, got, want[1])
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	for _, tc := range []struct {
+		rules Rules
+		a, b  string
+		want  int
+	}{
+		{Standard, "33332", "2AAAA", 1},
+		{Standard, "77888", "77788", 1},
+		{Standard, "KK677", "KTJJT", 1},
+		{Jokers, "KK677", "KTJJT", -1},
+		// A joker is the weakest card when breaking ties.
+		{Jokers, "JKKK2", "QQQQ2", -1},
+		{Standard, "T55J5", "T55J5", 0},
+	} {
+		if got := tc.rules.Compare(Hand{Cards: tc.a}, Hand{Cards: tc.b}); got != tc.want {
+			t.Errorf("Compare(%s, %s): got: %d want: %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	type test struct {
+		rules Rules
+		order []string
+		total int
+	}
+
+	for tn, tc := range map[string]test{
+		"standard": {Standard, []string{"32T3K", "KTJJT", "KK677", "T55J5", "QQQJA"}, 6440},
+		"jokers":   {Jokers, []string{"32T3K", "KK677", "T55J5", "QQQJA", "KTJJT"}, 5905},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				ranked, err := tc.rules.Rank(exampleHands(t))
+				if err != nil {
+					t.Fatalf("Rank(): unexpected error: %v", err)
+				}
+				var order []string
+				for i, r := range ranked {
+					order = append(order, r.Cards)
+					if r.Rank != i+1 {
+						t.Errorf("Rank(): %s has rank %d, want %d", r.Cards, r.Rank, i+1)
+					}
+				}
+				if diff := cmp.Diff(order, tc.order); diff != "" {
+					t.Errorf("Rank(): mismatch (-got,+want):\n%v", diff)
+				}
+				if got := TotalWinnings(ranked); got != tc.total {
+					t.Errorf("TotalWinnings(): got: %d want: %d", got, tc.total)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for tn, doc := range map[string]string{
+		"missing bid":  "32T3K",
+		"invalid bid":  "32T3K x",
+		"extra fields": "32T3K 765 1",
+	} {
+		if _, err := FromDocument(bytes.NewBufferString(doc)); !errors.Is(err, ErrInvalidHand) {
+			t.Errorf("%s: FromDocument(): got error: %v want: %v", tn, err, ErrInvalidHand)
+		}
+	}
+	for tn, cards := range map[string]string{
+		"short":        "32T3",
+		"long":         "32T3KK",
+		"unknown card": "32T3X",
+	} {
+		if _, err := Standard.Rank([]Hand{{Cards: cards}}); !errors.Is(err, ErrInvalidHand) {
+			t.Errorf("%s: Rank(): got error: %v want: %v", tn, err, ErrInvalidHand)
+		}
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	ranked, err := Jokers.Rank(exampleHands(t))
+	if err != nil {
+		t.Fatalf("Rank(): unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, ranked); err != nil {
+		t.Fatalf("WriteTable(): unexpected error: %v", err)
+	}
+	want := `   Hand            Type  Bid  Rank  Winnings
+  32T3K        One pair  765     1       765
+  KK677        Two pair   28     2        56
+  T55J5  Four of a kind  684     3      2052
+  QQQJA  Four of a kind  483     4      1932
+  KTJJT  Four of a kind  220     5      1100
+  Total                                 5905
+`
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("WriteTable(): mismatch (-got,+want):\n%v", diff)
+	}
+}
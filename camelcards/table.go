@@ -0,0 +1,20 @@
+package camelcards
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteTable writes a breakdown of ranked hands to w, one row per hand from
+// weakest to strongest, with the total winnings at the bottom. Each row shows
+// the hand's cards, its kind, its bid, its rank and its winnings.
+func WriteTable(w io.Writer, ranked []Ranked) error {
+	tw := tabwriter.NewWriter(w, 2, 1, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Hand\tType\tBid\tRank\tWinnings\t")
+	for _, r := range ranked {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t\n", r.Cards, r.Kind, r.Bid, r.Rank, r.Winnings())
+	}
+	fmt.Fprintf(tw, "Total\t\t\t\t%d\t\n", TotalWinnings(ranked))
+	return tw.Flush()
+}
@@ -4,6 +4,7 @@ package main
 import (
 	"os"
 
+	"github.com/cfunkhouser/aoc2023/schematic"
 	"github.com/cfunkhouser/aoc2023/stars"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +20,7 @@ var (
 
 func main() {
 	stars.RegisterOn(rootCmd)
+	schematic.RegisterOn(rootCmd)
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
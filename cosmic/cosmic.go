@@ -0,0 +1,156 @@
+// Package cosmic measures the distances between galaxies in the expanding
+// universe of stars twenty-one and twenty-two.
+// See: https://adventofcode.com/2023/day/11
+package cosmic
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cfunkhouser/aoc2023/grid"
+	"github.com/cfunkhouser/aoc2023/util"
+)
+
+// Image of the universe, before expansion.
+type Image struct {
+	grid.Bounds
+	// Galaxies in reading order.
+	Galaxies []grid.Point
+}
+
+var (
+	// ErrInvalidImage is returned when an image cannot be parsed.
+	ErrInvalidImage = errors.New("invalid image")
+	// ErrInvalidFactor is returned when an expansion factor is negative.
+	ErrInvalidFactor = errors.New("invalid expansion factor")
+)
+
+// occupied returns the distinct values of a coordinate, in ascending order.
+func occupied(galaxies []grid.Point, coord func(grid.Point) int) []int {
+	ret := make([]int, len(galaxies))
+	for i, g := range galaxies {
+		ret[i] = coord(g)
+	}
+	slices.Sort(ret)
+	return slices.Compact(ret)
+}
+
+// expand a coordinate, given the occupied values of it. Each empty row or
+// column before v becomes factor of them, so v moves by (factor - 1) for each.
+func expand(v int, occupied []int, factor int) (int, bool) {
+	empty := v - sort.SearchInts(occupied, v)
+	shift, ok := util.MulChecked(empty, factor-1)
+	if !ok {
+		return 0, false
+	}
+	return util.AddChecked(v, shift)
+}
+
+// Expand the universe, replacing every row and column without a galaxy with
+// factor of them, and return the new positions of the galaxies. A factor of 1
+// leaves the universe as it is, and 0 removes the empty rows and columns.
+// Empty rows and columns are counted by binary search of the occupied ones,
+// rather than by scanning the image, so this takes O(n log n) time in the
+// number of galaxies. Returns util.ErrOverflow if a position does not fit in an
+// int.
+func (im *Image) Expand(factor int) ([]grid.Point, error) {
+	if factor < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidFactor, factor)
+	}
+	xs := occupied(im.Galaxies, func(p grid.Point) int { return p.X })
+	ys := occupied(im.Galaxies, func(p grid.Point) int { return p.Y })
+	ret := make([]grid.Point, len(im.Galaxies))
+	for i, g := range im.Galaxies {
+		x, okx := expand(g.X, xs, factor)
+		y, oky := expand(g.Y, ys, factor)
+		if !okx || !oky {
+			return nil, fmt.Errorf("galaxy at %v: %w", g, util.ErrOverflow)
+		}
+		ret[i] = grid.Point{X: x, Y: y}
+	}
+	return ret, nil
+}
+
+// axisSum is the sum of the absolute differences between every pair of values.
+// Once sorted, the i-th value exceeds each of the i before it, contributing
+// i*v less the sum of those before it.
+func axisSum(vs []int) (int, bool) {
+	slices.Sort(vs)
+	var total, prefix int
+	for i, v := range vs {
+		d, ok1 := util.MulChecked(i, v)
+		d, ok2 := util.AddChecked(d, -prefix)
+		var ok3, ok4 bool
+		total, ok3 = util.AddChecked(total, d)
+		prefix, ok4 = util.AddChecked(prefix, v)
+		if !ok1 || !ok2 || !ok3 || !ok4 {
+			return 0, false
+		}
+	}
+	return total, true
+}
+
+// SumDistances is the sum of the Manhattan distances between every pair of
+// points. Manhattan distance separates into the distance along each axis, so
+// each axis is summed independently using prefix sums of its sorted values.
+// This takes O(n log n) time rather than the O(n²) of visiting every pair.
+// Returns util.ErrOverflow if the sum does not fit in an int.
+func SumDistances(points []grid.Point) (int, error) {
+	xs := make([]int, len(points))
+	ys := make([]int, len(points))
+	for i, p := range points {
+		xs[i], ys[i] = p.X, p.Y
+	}
+	sx, okx := axisSum(xs)
+	sy, oky := axisSum(ys)
+	sum, ok := util.AddChecked(sx, sy)
+	if !okx || !oky || !ok {
+		return 0, fmt.Errorf("sum of distances: %w", util.ErrOverflow)
+	}
+	return sum, nil
+}
+
+// FromDocument parses an image, in which galaxies are marked # and empty space
+// is marked . Blank lines before and after the image are ignored, but not
+// within it, where they would change which rows are empty.
+func FromDocument(doc io.Reader) (*Image, error) {
+	im := &Image{}
+	var blank int
+	s := bufio.NewScanner(doc)
+	for line := 1; s.Scan(); line++ {
+		l := strings.TrimSpace(s.Text())
+		switch {
+		case l == "":
+			if im.Height > 0 && blank == 0 {
+				blank = line
+			}
+			continue
+		case blank != 0:
+			return nil, fmt.Errorf("line %d: %w: blank line within image", blank, ErrInvalidImage)
+		}
+		y := im.Height
+		for x, r := range []rune(l) {
+			switch r {
+			case '#':
+				im.Galaxies = append(im.Galaxies, grid.Point{X: x, Y: y})
+			case '.':
+			default:
+				return nil, fmt.Errorf("line %d: %w: unknown character %q", line, ErrInvalidImage, r)
+			}
+		}
+		im.Width = max(im.Width, len([]rune(l)))
+		im.Height++
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if im.Height == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidImage)
+	}
+	return im, nil
+}
@@ -0,0 +1,119 @@
+package cosmic
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/cfunkhouser/aoc2023/grid"
+	"github.com/cfunkhouser/aoc2023/util"
+	"github.com/google/go-cmp/cmp"
+)
+
+const example = `...#......
+.......#..
+#.........
+..........
+......#...
+.#........
+.........#
+..........
+.......#..
+#...#.....`
+
+func image(t *testing.T, doc string) *Image {
+	t.Helper()
+	im, err := FromDocument(bytes.NewBufferString(doc))
+	if err != nil {
+		t.Fatalf("FromDocument(): unexpected error: %v", err)
+	}
+	return im
+}
+
+func TestExpand(t *testing.T) {
+	got, err := image(t, "#..\n...\n..#").Expand(3)
+	if err != nil {
+		t.Fatalf("Expand(): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(got, []grid.Point{{X: 0, Y: 0}, {X: 4, Y: 4}}); diff != "" {
+		t.Errorf("Expand(): mismatch (-got,+want):\n%v", diff)
+	}
+	got, err = image(t, "#..\n...\n..#").Expand(0)
+	if err != nil {
+		t.Fatalf("Expand(0): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(got, []grid.Point{{X: 0, Y: 0}, {X: 1, Y: 1}}); diff != "" {
+		t.Errorf("Expand(0): mismatch (-got,+want):\n%v", diff)
+	}
+}
+
+func TestSumDistances(t *testing.T) {
+	im := image(t, example)
+	for factor, want := range map[int]int{
+		1:         292,
+		2:         374,
+		10:        1030,
+		100:       8410,
+		1_000_000: 82000210,
+	} {
+		galaxies, err := im.Expand(factor)
+		if err != nil {
+			t.Fatalf("Expand(%d): unexpected error: %v", factor, err)
+		}
+		if got, err := SumDistances(galaxies); err != nil || got != want {
+			t.Errorf("SumDistances() at factor %d: got: %d, %v want: %d", factor, got, err, want)
+		}
+	}
+}
+
+// TestSumDistancesPairwise compares against visiting every pair.
+func TestSumDistancesPairwise(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	for n := 0; n < 50; n++ {
+		points := make([]grid.Point, r.Intn(40))
+		for i := range points {
+			points[i] = grid.Point{X: r.Intn(100) - 50, Y: r.Intn(100) - 50}
+		}
+		var want int
+		for i := range points {
+			for j := i + 1; j < len(points); j++ {
+				want += points[i].Manhattan(points[j])
+			}
+		}
+		if got, err := SumDistances(points); err != nil || got != want {
+			t.Errorf("SumDistances(%v): got: %d, %v want: %d", points, got, err, want)
+		}
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	im := image(t, "#..\n...\n..#")
+	if _, err := im.Expand(math.MaxInt); !errors.Is(err, util.ErrOverflow) {
+		t.Errorf("Expand(MaxInt): got error: %v want: %v", err, util.ErrOverflow)
+	}
+	points := []grid.Point{{X: 0}, {X: math.MaxInt}, {X: math.MaxInt}}
+	if _, err := SumDistances(points); !errors.Is(err, util.ErrOverflow) {
+		t.Errorf("SumDistances(): got error: %v want: %v", err, util.ErrOverflow)
+	}
+	if _, err := im.Expand(-1); !errors.Is(err, ErrInvalidFactor) {
+		t.Errorf("Expand(-1): got error: %v want: %v", err, ErrInvalidFactor)
+	}
+}
+
+func TestFromDocumentErrors(t *testing.T) {
+	for tn, doc := range map[string]string{
+		"empty":             "",
+		"unknown character": "#.\n.x",
+		"blank line within": "#.\n\n.#",
+	} {
+		func(t *testing.T, tn, doc string) {
+			t.Run(tn, func(t *testing.T) {
+				if _, err := FromDocument(bytes.NewBufferString(doc)); !errors.Is(err, ErrInvalidImage) {
+					t.Errorf("FromDocument(): got error: %v want: %v", err, ErrInvalidImage)
+				}
+			})
+		}(t, tn, doc)
+	}
+}
@@ -0,0 +1,154 @@
+package gondola
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// Node in a Graph, representing a single Cell.
+type Node struct {
+	ID    int    `json:"id"`
+	Value string `json:"value"`
+	// Kind is either "number" or "symbol".
+	Kind string `json:"kind"`
+	// X, Y is the position of the leftmost column occupied by the cell.
+	X int `json:"x"`
+	Y int `json:"y"`
+	// Cluster identifies the connected group of cells the node belongs to.
+	Cluster int `json:"cluster"`
+}
+
+// Edge in a Graph, linking two adjacent cells. Direction is the direction of
+// To as seen from From.
+type Edge struct {
+	From      int       `json:"from"`
+	To        int       `json:"to"`
+	Direction Direction `json:"direction"`
+}
+
+// Graph of cell adjacency in a schematic.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+}
+
+// Graph of the adjacency links between cells in the grid. Nodes are in reading
+// order. Each pair of adjacent cells is linked once for every direction in
+// which they are adjacent, with From always the earlier of the two. A
+// multi-digit number is never linked to itself.
+func (g *Grid) Graph() *Graph {
+	cells := g.Cells()
+	ids := make(map[*Cell]int, len(cells))
+	ret := &Graph{Nodes: make([]Node, len(cells))}
+	for id, c := range cells {
+		ids[c] = id
+		x, y, _ := g.Position(c)
+		kind := "symbol"
+		if _, ok := c.Number(); ok {
+			kind = "number"
+		}
+		ret.Nodes[id] = Node{ID: id, Value: c.String(), Kind: kind, X: x, Y: y}
+	}
+
+	type pair struct{ from, to int }
+	links := make(map[pair][]Direction)
+	for from, c := range cells {
+		for i, ac := range c.Adjacent {
+			to, ok := ids[ac]
+			if !ok || to == from {
+				continue
+			}
+			p, d := pair{from, to}, Direction(i)
+			if to < from {
+				p, d = pair{to, from}, d.Reverse()
+			}
+			if !slices.Contains(links[p], d) {
+				links[p] = append(links[p], d)
+			}
+		}
+	}
+	for p, ds := range links {
+		for _, d := range ds {
+			ret.Edges = append(ret.Edges, Edge{From: p.from, To: p.to, Direction: d})
+		}
+	}
+	slices.SortFunc(ret.Edges, func(l, r Edge) int {
+		if l.From != r.From {
+			return l.From - r.From
+		}
+		if l.To != r.To {
+			return l.To - r.To
+		}
+		return int(l.Direction - r.Direction)
+	})
+	ret.cluster()
+	return ret
+}
+
+// cluster assigns each node the ID of the lowest-numbered node it is connected
+// to.
+func (gr *Graph) cluster() {
+	parent := make([]int, len(gr.Nodes))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for _, e := range gr.Edges {
+		l, r := find(e.From), find(e.To)
+		if l > r {
+			l, r = r, l
+		}
+		parent[r] = l
+	}
+	for i := range gr.Nodes {
+		gr.Nodes[i].Cluster = find(i)
+	}
+}
+
+// WriteDOT renders the graph in the Graphviz DOT language. Nodes are pinned to
+// their positions in the schematic, and clusters of more than one node are
+// grouped into subgraphs.
+func (gr *Graph) WriteDOT(w io.Writer) error {
+	var buf bytes.Buffer
+	clusters := make(map[int][]Node)
+	var order []int
+	for _, n := range gr.Nodes {
+		if _, ok := clusters[n.Cluster]; !ok {
+			order = append(order, n.Cluster)
+		}
+		clusters[n.Cluster] = append(clusters[n.Cluster], n)
+	}
+
+	fmt.Fprintln(&buf, "graph schematic {")
+	fmt.Fprintln(&buf, "\tnode [shape=box];")
+	for _, id := range order {
+		nodes := clusters[id]
+		indent := "\t"
+		if len(nodes) > 1 {
+			fmt.Fprintf(&buf, "\tsubgraph cluster_%d {\n", id)
+			indent = "\t\t"
+		}
+		for _, n := range nodes {
+			fmt.Fprintf(&buf, "%sn%d [label=%s, kind=%s, pos=\"%d,%d!\"];\n",
+				indent, n.ID, strconv.Quote(n.Value), n.Kind, n.X, -n.Y)
+		}
+		if len(nodes) > 1 {
+			fmt.Fprintln(&buf, "\t}")
+		}
+	}
+	for _, e := range gr.Edges {
+		fmt.Fprintf(&buf, "\tn%d -- n%d [label=%s];\n", e.From, e.To, e.Direction)
+	}
+	fmt.Fprintln(&buf, "}")
+	_, err := buf.WriteTo(w)
+	return err
+}
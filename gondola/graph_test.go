@@ -0,0 +1,99 @@
+package gondola
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGridGraph(t *testing.T) {
+	type test struct {
+		doc  string
+		want *Graph
+	}
+
+	for tn, tc := range map[string]test{
+		"empty": {want: &Graph{Nodes: []Node{}}},
+		"clusters": {
+			doc: `467.
+...*
+.35.
+....
+7..#`,
+			want: &Graph{
+				Nodes: []Node{
+					{0, "467", "number", 0, 0, 0},
+					{1, "*", "symbol", 3, 1, 0},
+					{2, "35", "number", 1, 2, 0},
+					{3, "7", "number", 0, 4, 3},
+					{4, "#", "symbol", 3, 4, 4},
+				},
+				Edges: []Edge{
+					{0, 1, SE},
+					{1, 2, SW},
+				},
+			},
+		},
+		"several directions": {
+			doc: `12
+.*`,
+			want: &Graph{
+				Nodes: []Node{
+					{0, "12", "number", 0, 0, 0},
+					{1, "*", "symbol", 1, 1, 0},
+				},
+				Edges: []Edge{
+					{0, 1, SE},
+					{0, 1, S},
+				},
+			},
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got := GridFromDocument(bytes.NewBufferString(tc.doc)).Graph()
+				if diff := cmp.Diff(got, tc.want); diff != "" {
+					t.Errorf("Graph(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestGraphJSON(t *testing.T) {
+	g := GridFromDocument(bytes.NewBufferString("1*")).Graph()
+	got, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("json.Marshal(): unexpected error: %v", err)
+	}
+	want := `{"nodes":[{"id":0,"value":"1","kind":"number","x":0,"y":0,"cluster":0},` +
+		`{"id":1,"value":"*","kind":"symbol","x":1,"y":0,"cluster":0}],` +
+		`"edges":[{"from":0,"to":1,"direction":"E"}]}`
+	if diff := cmp.Diff(string(got), want); diff != "" {
+		t.Errorf("json.Marshal(): mismatch (-got,+want):\n%v", diff)
+	}
+}
+
+func TestGraphWriteDOT(t *testing.T) {
+	g := GridFromDocument(bytes.NewBufferString("1*.\n..#")).Graph()
+	var buf bytes.Buffer
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT(): unexpected error: %v", err)
+	}
+	want := `graph schematic {
+	node [shape=box];
+	subgraph cluster_0 {
+		n0 [label="1", kind=number, pos="0,0!"];
+		n1 [label="*", kind=symbol, pos="1,0!"];
+		n2 [label="#", kind=symbol, pos="2,-1!"];
+	}
+	n0 -- n1 [label=E];
+	n1 -- n2 [label=SE];
+}
+`
+	if diff := cmp.Diff(buf.String(), want); diff != "" {
+		t.Errorf("WriteDOT(): mismatch (-got,+want):\n%v", diff)
+	}
+}
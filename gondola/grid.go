@@ -0,0 +1,125 @@
+package gondola
+
+import (
+	"io"
+)
+
+// Grid is a Schematic along with the layout of its cells. A multi-digit number
+// occupies several consecutive columns of a row, each of which refers to the
+// same Cell.
+type Grid struct {
+	*Schematic
+
+	rows      rawSchematic
+	extents   point
+	topology  Topology
+	positions map[*Cell]point
+	cells     []*Cell
+}
+
+// Size of the grid. The width is that of the longest row.
+func (g *Grid) Size() (width, height int) {
+	return g.extents.X, g.extents.Y
+}
+
+// At returns the cell at column x of row y, or nil if that position is empty or
+// outside the grid.
+func (g *Grid) At(x, y int) *Cell {
+	return g.rows.at(point{x, y})
+}
+
+// Position of the leftmost column occupied by c. The result is only valid if
+// ok is true.
+func (g *Grid) Position(c *Cell) (x, y int, ok bool) {
+	p, ok := g.positions[c]
+	return p.X, p.Y, ok
+}
+
+// Width is the number of columns occupied by c.
+func (g *Grid) Width(c *Cell) (w int) {
+	p, ok := g.positions[c]
+	if !ok {
+		return 0
+	}
+	for g.At(p.X+w, p.Y) == c {
+		w++
+	}
+	return
+}
+
+// Cells in the grid, each appearing once, in reading order.
+func (g *Grid) Cells() []*Cell {
+	return g.cells
+}
+
+// Touching returns the distinct cells adjacent to any column occupied by c, in
+// reading order of the columns around it. Unlike c.Adjacent, which holds a
+// single cell per Direction, this accounts for every neighbour of a multi-digit
+// number.
+func (g *Grid) Touching(c *Cell) (ret []*Cell) {
+	p, ok := g.positions[c]
+	if !ok {
+		return
+	}
+	w := g.Width(c)
+	seen := map[*Cell]bool{c: true}
+	for y := p.Y - 1; y <= p.Y+1; y++ {
+		for x := p.X - 1; x <= p.X+w; x++ {
+			rx, ry, ok := g.topology.Resolve(x, y, g.extents.X, g.extents.Y)
+			if !ok {
+				continue
+			}
+			if ac := g.At(rx, ry); ac != nil && !seen[ac] {
+				seen[ac] = true
+				ret = append(ret, ac)
+			}
+		}
+	}
+	return
+}
+
+// grid lays out a raw schematic, compacting it using the topology t in the
+// process.
+func (rs rawSchematic) grid(t Topology) *Grid {
+	g := &Grid{
+		Schematic: rs.compact(t),
+		rows:      rs,
+		extents:   rs.extents(),
+		topology:  t,
+		positions: make(map[*Cell]point),
+	}
+	for y, row := range rs {
+		for x, cell := range row {
+			if cell == nil {
+				continue
+			}
+			if _, seen := g.positions[cell]; !seen {
+				g.positions[cell] = point{x, y}
+				g.cells = append(g.cells, cell)
+			}
+		}
+	}
+	return g
+}
+
+// GridFromDocument produces a Grid from the contents of doc, panicking if not
+// valid.
+func GridFromDocument(doc io.Reader, opts ...Option) *Grid {
+	return rawFromDocument(doc).grid(newOptions(opts).topology)
+}
+
+// IsPartNumber is true if c is a number adjacent to at least one symbol.
+func (g *Grid) IsPartNumber(c *Cell) bool {
+	if c == nil {
+		return false
+	}
+	if _, ok := c.Number(); !ok {
+		return false
+	}
+	for _, ac := range g.Touching(c) {
+		if _, ok := ac.Rune(); ok {
+			return true
+		}
+	}
+	return false
+}
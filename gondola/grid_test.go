@@ -0,0 +1,75 @@
+package gondola
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGridTouching(t *testing.T) {
+	type test struct {
+		x, y int
+		want string
+	}
+
+	g := GridFromDocument(bytes.NewBufferString(`#.....
+.467*.
+$...&.`))
+
+	for tn, tc := range map[string]test{
+		"number spanning columns": {1, 1, "# * $ &"},
+		"symbol":                  {4, 1, "467 &"},
+		"isolated symbol":         {0, 2, "467"},
+		"empty position":          {5, 0, ""},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got := joinCells(g.Touching(g.At(tc.x, tc.y)))
+				if diff := cmp.Diff(got, tc.want); diff != "" {
+					t.Errorf("Touching(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestGridPosition(t *testing.T) {
+	g := GridFromDocument(bytes.NewBufferString("..467*"))
+	c := g.At(3, 0)
+	x, y, ok := g.Position(c)
+	if !ok || x != 2 || y != 0 {
+		t.Errorf("Position(): got: (%d,%d,%v) want: (2,0,true)", x, y, ok)
+	}
+	if w := g.Width(c); w != 3 {
+		t.Errorf("Width(): got: %d want: 3", w)
+	}
+	if w, h := g.Size(); w != 6 || h != 1 {
+		t.Errorf("Size(): got: (%d,%d) want: (6,1)", w, h)
+	}
+}
+
+func TestGridIsPartNumber(t *testing.T) {
+	type test struct {
+		x, y int
+		want bool
+	}
+
+	g := GridFromDocument(bytes.NewBufferString(`467..114..
+...*......`))
+
+	for tn, tc := range map[string]test{
+		"part number":     {1, 0, true},
+		"not part number": {6, 0, false},
+		"symbol":          {3, 1, false},
+		"empty":           {0, 1, false},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				if got := g.IsPartNumber(g.At(tc.x, tc.y)); got != tc.want {
+					t.Errorf("IsPartNumber(): mismatch: got: %v want: %v", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
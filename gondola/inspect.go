@@ -0,0 +1,148 @@
+package gondola
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"text/tabwriter"
+)
+
+// Finding is a cell of interest in a Report, along with its position and the
+// cells adjacent to it which made it interesting.
+type Finding struct {
+	Cell *Cell
+	X, Y int
+	// Adjacent cells which are relevant to the finding: symbols for numbers,
+	// and numbers for near-gears.
+	Adjacent []*Cell
+}
+
+// String produces a string representation of a Finding.
+func (f *Finding) String() string {
+	return fmt.Sprintf("%s at (%d,%d)", f.Cell, f.X, f.Y)
+}
+
+// Report of diagnostics about a schematic.
+type Report struct {
+	// Unattached numbers touch no symbol, so are not part numbers.
+	Unattached []Finding
+	// MultiplyAttached numbers touch more than one symbol.
+	MultiplyAttached []Finding
+	// NearGears are '*' cells adjacent to one number, or three or more.
+	NearGears []Finding
+	// Duplicates are part number values which appear at more than one
+	// position, keyed by value.
+	Duplicates map[int][]Finding
+	// Symbols is the number of times each symbol appears.
+	Symbols map[rune]int
+}
+
+// Inspect the grid, producing a Report. Findings are in reading order.
+func (g *Grid) Inspect() *Report {
+	r := &Report{
+		Duplicates: make(map[int][]Finding),
+		Symbols:    make(map[rune]int),
+	}
+	parts := make(map[int][]Finding)
+	for _, c := range g.Cells() {
+		x, y, _ := g.Position(c)
+		f := Finding{Cell: c, X: x, Y: y}
+		if n, ok := c.Number(); ok {
+			for _, ac := range g.Touching(c) {
+				if _, ok := ac.Rune(); ok {
+					f.Adjacent = append(f.Adjacent, ac)
+				}
+			}
+			if len(f.Adjacent) == 0 {
+				r.Unattached = append(r.Unattached, f)
+				continue
+			}
+			if len(f.Adjacent) > 1 {
+				r.MultiplyAttached = append(r.MultiplyAttached, f)
+			}
+			parts[n] = append(parts[n], f)
+			continue
+		}
+		sym, _ := c.Rune()
+		r.Symbols[sym]++
+		if sym != '*' {
+			continue
+		}
+		for _, ac := range g.Touching(c) {
+			if _, ok := ac.Number(); ok {
+				f.Adjacent = append(f.Adjacent, ac)
+			}
+		}
+		if n := len(f.Adjacent); n == 1 || n > 2 {
+			r.NearGears = append(r.NearGears, f)
+		}
+	}
+	for n, fs := range parts {
+		if len(fs) > 1 {
+			r.Duplicates[n] = fs
+		}
+	}
+	return r
+}
+
+func joinCells(cells []*Cell) string {
+	var buf bytes.Buffer
+	for i, c := range cells {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(c.String())
+	}
+	return buf.String()
+}
+
+// String produces a human-readable rendering of the Report.
+func (r *Report) String() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 2, 1, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Numbers touching no symbol: %d\n", len(r.Unattached))
+	for _, f := range r.Unattached {
+		fmt.Fprintf(w, "\t%s\n", &f)
+	}
+
+	fmt.Fprintf(w, "Numbers touching several symbols: %d\n", len(r.MultiplyAttached))
+	for _, f := range r.MultiplyAttached {
+		fmt.Fprintf(w, "\t%s\tsymbols: %s\n", &f, joinCells(f.Adjacent))
+	}
+
+	fmt.Fprintf(w, "Near-gears: %d\n", len(r.NearGears))
+	for _, f := range r.NearGears {
+		fmt.Fprintf(w, "\t%s\tnumbers: %s\n", &f, joinCells(f.Adjacent))
+	}
+
+	dups := make([]int, 0, len(r.Duplicates))
+	for n := range r.Duplicates {
+		dups = append(dups, n)
+	}
+	slices.Sort(dups)
+	fmt.Fprintf(w, "Duplicate part numbers: %d\n", len(dups))
+	for _, n := range dups {
+		fmt.Fprintf(w, "\t%d\t", n)
+		for i, f := range r.Duplicates[n] {
+			if i > 0 {
+				fmt.Fprint(w, ", ")
+			}
+			fmt.Fprintf(w, "(%d,%d)", f.X, f.Y)
+		}
+		fmt.Fprintln(w)
+	}
+
+	syms := make([]rune, 0, len(r.Symbols))
+	for sym := range r.Symbols {
+		syms = append(syms, sym)
+	}
+	slices.Sort(syms)
+	fmt.Fprintf(w, "Symbols: %d\n", len(syms))
+	for _, sym := range syms {
+		fmt.Fprintf(w, "\t%c\t%d\n", sym, r.Symbols[sym])
+	}
+
+	w.Flush()
+	return buf.String()
+}
@@ -0,0 +1,104 @@
+package gondola
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// findingSummary flattens a Finding for comparison in tests.
+type findingSummary struct {
+	Value    string
+	X, Y     int
+	Adjacent string
+}
+
+func summarize(fs []Finding) (ret []findingSummary) {
+	for _, f := range fs {
+		ret = append(ret, findingSummary{f.Cell.String(), f.X, f.Y, joinCells(f.Adjacent)})
+	}
+	return
+}
+
+func TestGridInspect(t *testing.T) {
+	type test struct {
+		doc                  string
+		wantUnattached       []findingSummary
+		wantMultiplyAttached []findingSummary
+		wantNearGears        []findingSummary
+		wantDuplicates       map[int][]findingSummary
+		wantSymbols          map[rune]int
+	}
+
+	for tn, tc := range map[string]test{
+		"empty": {
+			wantDuplicates: map[int][]findingSummary{},
+			wantSymbols:    map[rune]int{},
+		},
+		"example from problem": {
+			doc: `467..114..
+...*......
+..35..633.
+......#...
+617*......
+.....+.58.
+..592.....
+......755.
+...$.*....
+.664.598..`,
+			wantUnattached: []findingSummary{
+				{"114", 5, 0, ""},
+				{"58", 7, 5, ""},
+			},
+			wantNearGears: []findingSummary{
+				{"*", 3, 4, "617"},
+			},
+			wantDuplicates: map[int][]findingSummary{},
+			wantSymbols:    map[rune]int{'#': 1, '$': 1, '*': 3, '+': 1},
+		},
+		"multiply attached and duplicated": {
+			doc: `12*3*
+4....
+12#..`,
+			wantUnattached: []findingSummary{
+				{"4", 0, 1, ""},
+			},
+			wantMultiplyAttached: []findingSummary{
+				{"3", 3, 0, "* *"},
+			},
+			wantNearGears: []findingSummary{
+				{"*", 4, 0, "3"},
+			},
+			wantDuplicates: map[int][]findingSummary{
+				12: {{"12", 0, 0, "*"}, {"12", 0, 2, "#"}},
+			},
+			wantSymbols: map[rune]int{'#': 1, '*': 2},
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got := GridFromDocument(bytes.NewBufferString(tc.doc)).Inspect()
+				if diff := cmp.Diff(summarize(got.Unattached), tc.wantUnattached); diff != "" {
+					t.Errorf("Inspect(): Unattached mismatch (-got,+want):\n%v", diff)
+				}
+				if diff := cmp.Diff(summarize(got.MultiplyAttached), tc.wantMultiplyAttached); diff != "" {
+					t.Errorf("Inspect(): MultiplyAttached mismatch (-got,+want):\n%v", diff)
+				}
+				if diff := cmp.Diff(summarize(got.NearGears), tc.wantNearGears); diff != "" {
+					t.Errorf("Inspect(): NearGears mismatch (-got,+want):\n%v", diff)
+				}
+				gotDups := make(map[int][]findingSummary)
+				for n, fs := range got.Duplicates {
+					gotDups[n] = summarize(fs)
+				}
+				if diff := cmp.Diff(gotDups, tc.wantDuplicates); diff != "" {
+					t.Errorf("Inspect(): Duplicates mismatch (-got,+want):\n%v", diff)
+				}
+				if diff := cmp.Diff(got.Symbols, tc.wantSymbols); diff != "" {
+					t.Errorf("Inspect(): Symbols mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"regexp"
 	"slices"
 	"strconv"
 	"text/tabwriter"
@@ -167,6 +166,24 @@ const (
 	W
 )
 
+var directionNames = [...]string{"NW", "N", "NE", "E", "SE", "S", "SW", "W"}
+
+// String produces the compass abbreviation for the direction.
+func (d Direction) String() string {
+	if d < NW || d > W {
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+	return directionNames[d]
+}
+
+// MarshalText encodes the direction as its compass abbreviation.
+func (d Direction) MarshalText() ([]byte, error) {
+	if d < NW || d > W {
+		return nil, fmt.Errorf("invalid direction %d", int(d))
+	}
+	return []byte(d.String()), nil
+}
+
 // Reverse relationship of the direction.
 func (d Direction) Reverse() Direction {
 	switch d {
@@ -216,31 +233,69 @@ func (p *point) ValidWithin(extents point) bool {
 
 type rawSchematic [][]*Cell
 
-func (rs rawSchematic) linkAdjacent(loc point, cell *Cell) {
-	var h, w int
-	if h = len(rs); h == 0 {
-		return
+// extents of the raw schematic. The width is that of the longest row.
+func (rs rawSchematic) extents() (ext point) {
+	for _, row := range rs {
+		ext.X = max(ext.X, len(row))
 	}
-	if w = len(rs[0]); w == 0 {
+	ext.Y = len(rs)
+	return
+}
+
+// at returns the cell at loc, or nil if there is none.
+func (rs rawSchematic) at(loc point) *Cell {
+	if loc.Y < 0 || loc.Y >= len(rs) {
+		return nil
+	}
+	if row := rs[loc.Y]; loc.X >= 0 && loc.X < len(row) {
+		return row[loc.X]
+	}
+	return nil
+}
+
+func (rs rawSchematic) linkAdjacent(loc point, cell *Cell, extents point, t Topology) {
+	if extents.X == 0 || extents.Y == 0 {
 		return
 	}
 
 	for i, adj := range loc.Adjacent() {
 		d := Direction(i)
-		if adj.ValidWithin(point{w, h}) {
-			ac := rs[adj.Y][adj.X]
-			if ac == nil {
-				continue
+		x, y, ok := t.Resolve(adj.X, adj.Y, extents.X, extents.Y)
+		if !ok {
+			continue
+		}
+		ac := rs.at(point{x, y})
+		if ac == nil {
+			continue
+		}
+		cell.Adjacent[d] = ac
+		ac.Adjacent[d.Reverse()] = cell
+	}
+}
+
+// mask removes cells at positions which are off-limits in the topology.
+func (rs rawSchematic) mask(extents point, t Topology) {
+	for y, row := range rs {
+		for x := range row {
+			if _, _, ok := t.Resolve(x, y, extents.X, extents.Y); !ok {
+				row[x] = nil
 			}
-			cell.Adjacent[d] = ac
-			ac.Adjacent[d.Reverse()] = cell
 		}
 	}
 }
 
 // Compact a raw schematic into a usable Schematic.
 func (rs rawSchematic) Compact() *Schematic {
+	return rs.compact(Rectangular)
+}
+
+// compact a raw schematic into a usable Schematic, linking cells which
+// neighbour one another in the topology. Cells at off-limits positions are
+// removed from the raw schematic.
+func (rs rawSchematic) compact(t Topology) *Schematic {
 	var ret Schematic
+	ext := rs.extents()
+	rs.mask(ext, t)
 	for y, row := range rs {
 		for x, cell := range row {
 			if cell == nil {
@@ -249,7 +304,7 @@ func (rs rawSchematic) Compact() *Schematic {
 			if _, ok := cell.Rune(); ok {
 				ret.Characters = append(ret.Characters, cell)
 			}
-			rs.linkAdjacent(point{x, y}, cell)
+			rs.linkAdjacent(point{x, y}, cell, ext, t)
 		}
 	}
 	return &ret
@@ -275,33 +330,38 @@ func (rs rawSchematic) String() string {
 	return buf.String()
 }
 
-var (
-	numRe  = regexp.MustCompile(`\d+`)
-	charRe = regexp.MustCompile(`[^\d.]`)
-)
-
 func lineToCells(l string) []*Cell {
-	numMatches := numRe.FindAllStringIndex(l, -1)
-	charMatches := charRe.FindAllStringIndex(l, -1)
-	ret := make([]*Cell, len(l))
-	for _, nm := range numMatches {
-		n, err := strconv.Atoi(l[nm[0]:nm[1]])
-		if err != nil {
-			panic(err)
-		}
+	nums, err := util.ScanInts(l, false)
+	if err != nil {
+		panic(err)
+	}
+	// Cells are laid out by rune, but numbers are found at byte offsets, so
+	// map the offset at which each rune starts to its column.
+	cols := make([]int, len(l)+1)
+	var width int
+	for i := range l {
+		cols[i] = width
+		width++
+	}
+	cols[len(l)] = width
+	ret := make([]*Cell, width)
+	for _, nm := range nums {
 		c := &Cell{
 			Value: Value{
-				n: util.Pointy(n),
+				n: util.Pointy(nm.Value),
 			},
 		}
-		for i := nm[0]; i < nm[1]; i++ {
+		for i := cols[nm.Start]; i < cols[nm.End]; i++ {
 			ret[i] = c
 		}
 	}
-	for _, cm := range charMatches {
-		ret[cm[0]] = &Cell{
+	for i, r := range []rune(l) {
+		if r == '.' || (r >= '0' && r <= '9') {
+			continue
+		}
+		ret[i] = &Cell{
 			Value: Value{
-				r: util.Pointy(rune(l[cm[0]])),
+				r: util.Pointy(r),
 			},
 		}
 	}
@@ -321,6 +381,6 @@ func rawFromDocument(doc io.Reader) (ret rawSchematic) {
 
 // FromDocument produces a Schematic from the contents of doc, panicking if not
 // valid.
-func FromDocument(doc io.Reader) *Schematic {
-	return rawFromDocument(doc).Compact()
+func FromDocument(doc io.Reader, opts ...Option) *Schematic {
+	return rawFromDocument(doc).compact(newOptions(opts).topology)
 }
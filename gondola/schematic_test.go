@@ -256,6 +256,12 @@ func TestSchematicPartNumbers(t *testing.T) {
 .664.598.`,
 			[]int{35, 467, 592, 598, 617, 633, 664, 755}, // sorted
 		},
+		// Columns are counted in runes, so 12 touches the #.
+		"non-ASCII symbols": {
+			`€€12
+....#`,
+			[]int{12},
+		},
 	} {
 		func(t *testing.T, tn string, tc *test) {
 			t.Run(tn, func(t *testing.T) {
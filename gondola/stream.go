@@ -0,0 +1,133 @@
+package gondola
+
+import (
+	"bufio"
+	"io"
+)
+
+// StreamHandler receives values from Stream as they are discovered. Either
+// function may be nil, in which case the corresponding values are discarded.
+type StreamHandler struct {
+	// PartNumber is called once for each number adjacent to a symbol.
+	PartNumber func(n int)
+	// GearRatio is called once for each gear in the schematic.
+	GearRatio func(ratio int)
+}
+
+// window of three consecutive schematic rows. The middle row is the one under
+// analysis; the rows on either side supply its neighbours.
+type window [3][]*Cell
+
+// push a new row onto the bottom of the window, discarding the top row.
+func (w *window) push(row []*Cell) {
+	w[0], w[1], w[2] = w[1], w[2], row
+}
+
+// at returns the cell at column x of row r in the window, or nil if there is
+// none.
+func (w *window) at(r, x int) *Cell {
+	if row := w[r]; x >= 0 && x < len(row) {
+		return row[x]
+	}
+	return nil
+}
+
+// neighbours of column x in the middle row, excluding any cell which is the
+// same as the one at x.
+func (w *window) neighbours(x int) (ret []*Cell) {
+	self := w.at(1, x)
+	for _, p := range (&point{x, 1}).Adjacent() {
+		if c := w.at(p.Y, p.X); c != nil && c != self {
+			ret = append(ret, c)
+		}
+	}
+	return
+}
+
+// emit the part numbers and gear ratios found in the middle row.
+func (w *window) emit(h StreamHandler) {
+	row := w[1]
+	for x, c := range row {
+		if c == nil {
+			continue
+		}
+		if _, ok := c.Number(); ok {
+			// Multi-digit numbers occupy several columns, but are only
+			// considered from their leftmost column.
+			if x > 0 && row[x-1] == c {
+				continue
+			}
+			if h.PartNumber != nil && w.touchesSymbol(x, c) {
+				n, _ := c.Number()
+				h.PartNumber(n)
+			}
+			continue
+		}
+		if h.GearRatio != nil {
+			if ratio := w.gearRatio(x); ratio != 0 {
+				h.GearRatio(ratio)
+			}
+		}
+	}
+}
+
+// touchesSymbol is true if any column spanned by the number starting at x is
+// adjacent to a symbol.
+func (w *window) touchesSymbol(x int, c *Cell) bool {
+	for ; w.at(1, x) == c; x++ {
+		for _, ac := range w.neighbours(x) {
+			if _, ok := ac.Rune(); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// gearRatio of the cell at column x of the middle row. Returns 0 if the cell
+// does not represent a gear.
+func (w *window) gearRatio(x int) (ratio int) {
+	if r, ok := w.at(1, x).Rune(); !ok || r != '*' {
+		return
+	}
+	adjnums := make(map[*Cell]int)
+	for _, ac := range w.neighbours(x) {
+		if n, ok := ac.Number(); ok {
+			adjnums[ac] = n
+		}
+	}
+	if len(adjnums) != 2 {
+		return
+	}
+	ratio = 1
+	for _, n := range adjnums {
+		ratio *= n
+	}
+	return
+}
+
+// Stream analyzes the schematic in doc one row at a time, calling the handler
+// for each part number and gear ratio as soon as the rows surrounding it have
+// been read. Unlike FromDocument, at most three rows are held in memory at
+// once, so memory use does not depend on the height of the schematic. Values
+// are emitted in document order rather than sorted. Streaming always uses the
+// Rectangular topology.
+func Stream(doc io.Reader, h StreamHandler) error {
+	var w window
+	var rows int
+	s := bufio.NewScanner(doc)
+	for s.Scan() {
+		w.push(lineToCells(s.Text()))
+		if rows++; rows > 1 {
+			w.emit(h)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	if rows > 0 {
+		w.push(nil)
+		w.emit(h)
+	}
+	return nil
+}
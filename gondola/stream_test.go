@@ -0,0 +1,99 @@
+package gondola
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStream(t *testing.T) {
+	type test struct {
+		doc       string
+		wantParts []int
+		wantGears []int
+	}
+
+	for tn, tc := range map[string]test{
+		"empty": {},
+		"example from problem": {
+			doc: `467..114..
+...*......
+..35..633.
+......#...
+617*......
+.....+.58.
+..592.....
+......755.
+...$.*....
+.664.598..`,
+			wantParts: []int{35, 467, 592, 598, 617, 633, 664, 755},
+			wantGears: []int{16345, 451490},
+		},
+		"single row": {
+			doc:       "12*34..5",
+			wantParts: []int{12, 34},
+			wantGears: []int{408},
+		},
+		"ragged rows": {
+			doc: `1.....
+.#
+.....2
+....*.
+...3`,
+			wantParts: []int{1, 2, 3},
+			wantGears: []int{6},
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				var parts, gears []int
+				err := Stream(bytes.NewBufferString(tc.doc), StreamHandler{
+					PartNumber: func(n int) { parts = append(parts, n) },
+					GearRatio:  func(r int) { gears = append(gears, r) },
+				})
+				if err != nil {
+					t.Fatalf("Stream(): unexpected error: %v", err)
+				}
+				slices.Sort(parts)
+				slices.Sort(gears)
+				if diff := cmp.Diff(parts, tc.wantParts); diff != "" {
+					t.Errorf("Stream(): part numbers mismatch (-got,+want):\n%v", diff)
+				}
+				if diff := cmp.Diff(gears, tc.wantGears); diff != "" {
+					t.Errorf("Stream(): gear ratios mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestStreamMatchesFromDocument(t *testing.T) {
+	doc := `467..114..
+...*......
+..35..633.
+......#...
+617*......
+.....+.58.
+..592.....
+......755.
+...$.*....
+.664.598..`
+	s := FromDocument(bytes.NewBufferString(doc))
+	var parts, gears []int
+	if err := Stream(bytes.NewBufferString(doc), StreamHandler{
+		PartNumber: func(n int) { parts = append(parts, n) },
+		GearRatio:  func(r int) { gears = append(gears, r) },
+	}); err != nil {
+		t.Fatalf("Stream(): unexpected error: %v", err)
+	}
+	slices.Sort(parts)
+	slices.Sort(gears)
+	if diff := cmp.Diff(parts, s.PartNumbers()); diff != "" {
+		t.Errorf("Stream(): part numbers differ from PartNumbers() (-stream,+doc):\n%v", diff)
+	}
+	if diff := cmp.Diff(gears, s.GearRatios()); diff != "" {
+		t.Errorf("Stream(): gear ratios differ from GearRatios() (-stream,+doc):\n%v", diff)
+	}
+}
@@ -0,0 +1,133 @@
+package gondola
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Topology determines which positions neighbour one another in a schematic.
+type Topology interface {
+	// Resolve maps the position x, y, which may lie outside of a schematic of
+	// the given width and height, to the position it refers to within it. The
+	// result is only valid if ok is true; otherwise the position is off-limits.
+	Resolve(x, y, width, height int) (rx, ry int, ok bool)
+}
+
+type rectangular struct{}
+
+func (rectangular) Resolve(x, y, width, height int) (int, int, bool) {
+	p := point{x, y}
+	return x, y, p.ValidWithin(point{width, height})
+}
+
+type toroidal struct{}
+
+func (toroidal) Resolve(x, y, width, height int) (int, int, bool) {
+	if width == 0 || height == 0 {
+		return x, y, false
+	}
+	return ((x % width) + width) % width, ((y % height) + height) % height, true
+}
+
+type masked struct {
+	base      Topology
+	offLimits func(x, y int) bool
+}
+
+func (m masked) Resolve(x, y, width, height int) (int, int, bool) {
+	rx, ry, ok := m.base.Resolve(x, y, width, height)
+	if !ok || m.offLimits(rx, ry) {
+		return rx, ry, false
+	}
+	return rx, ry, true
+}
+
+var (
+	// Rectangular schematics have no neighbours beyond their edges.
+	Rectangular Topology = rectangular{}
+	// Toroidal schematics wrap around, so that the leftmost column neighbours
+	// the rightmost, and the top row neighbours the bottom.
+	Toroidal Topology = toroidal{}
+)
+
+// Masked restricts the base topology so that positions for which offLimits
+// returns true are treated as empty. Cells at those positions are removed from
+// the schematic, and cannot neighbour any other.
+func Masked(base Topology, offLimits func(x, y int) bool) Topology {
+	return masked{base, offLimits}
+}
+
+// MaskFromDocument reads the off-limits positions for Masked from a document
+// laid out like the schematic it applies to, in which # marks a position which
+// is off-limits and . one which is not. Positions beyond the document are not
+// off-limits.
+func MaskFromDocument(doc io.Reader) (func(x, y int) bool, error) {
+	offLimits := make(map[point]bool)
+	s := bufio.NewScanner(doc)
+	for y := 0; s.Scan(); y++ {
+		for x, r := range []rune(s.Text()) {
+			switch r {
+			case '#':
+				offLimits[point{x, y}] = true
+			case '.':
+			default:
+				return nil, fmt.Errorf("mask line %d: unexpected %q, want # or .", y+1, r)
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return func(x, y int) bool { return offLimits[point{x, y}] }, nil
+}
+
+// MaskedFromFile restricts the base topology using the mask in the file at
+// path. See MaskFromDocument for the format of the mask.
+func MaskedFromFile(base Topology, path string) (Topology, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	offLimits, err := MaskFromDocument(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return Masked(base, offLimits), nil
+}
+
+// ParseTopology by name, either "rectangular" or "toroidal".
+func ParseTopology(name string) (Topology, error) {
+	switch name {
+	case "rectangular":
+		return Rectangular, nil
+	case "toroidal":
+		return Toroidal, nil
+	}
+	return nil, fmt.Errorf("unknown topology %q", name)
+}
+
+type options struct {
+	topology Topology
+}
+
+// Option configures how a schematic is parsed.
+type Option func(*options)
+
+// WithTopology parses the schematic using the provided Topology. The default
+// is Rectangular.
+func WithTopology(t Topology) Option {
+	return func(o *options) {
+		o.topology = t
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{topology: Rectangular}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
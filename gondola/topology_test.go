@@ -0,0 +1,233 @@
+package gondola
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTopologyResolve(t *testing.T) {
+	type test struct {
+		topology     Topology
+		x, y         int
+		wantX, wantY int
+		wantOk       bool
+	}
+
+	offDiagonal := Masked(Rectangular, func(x, y int) bool { return x == y })
+
+	for tn, tc := range map[string]test{
+		"rectangular within":       {Rectangular, 2, 3, 2, 3, true},
+		"rectangular outside":      {Rectangular, -1, 3, -1, 3, false},
+		"toroidal within":          {Toroidal, 2, 3, 2, 3, true},
+		"toroidal wraps negative":  {Toroidal, -1, -1, 9, 4, true},
+		"toroidal wraps past edge": {Toroidal, 10, 5, 0, 0, true},
+		"masked allowed":           {offDiagonal, 1, 2, 1, 2, true},
+		"masked off-limits":        {offDiagonal, 2, 2, 2, 2, false},
+		"masked outside base":      {offDiagonal, 10, 2, 10, 2, false},
+		"masked toroidal": {
+			Masked(Toroidal, func(x, y int) bool { return x == 0 }),
+			10, 1, 0, 1, false,
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				x, y, ok := tc.topology.Resolve(tc.x, tc.y, 10, 5)
+				if x != tc.wantX || y != tc.wantY || ok != tc.wantOk {
+					t.Errorf("Resolve(): mismatch: got: (%d,%d,%v) want: (%d,%d,%v)",
+						x, y, ok, tc.wantX, tc.wantY, tc.wantOk)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestSchematicTopologies(t *testing.T) {
+	type test struct {
+		doc       string
+		topology  Topology
+		wantParts []int
+		wantGears []int
+	}
+
+	wrapping := `2.....
+.....*
+3....4`
+
+	for tn, tc := range map[string]test{
+		"rectangular": {
+			doc:       wrapping,
+			topology:  Rectangular,
+			wantParts: []int{4},
+		},
+		"toroidal": {
+			doc:       wrapping,
+			topology:  Toroidal,
+			wantParts: []int{2, 3, 4},
+			wantGears: nil, // three numbers touch the gear
+		},
+		"toroidal gear": {
+			doc: `2.....
+.....*
+.....4`,
+			topology:  Toroidal,
+			wantParts: []int{2, 4},
+			wantGears: []int{8},
+		},
+		"masked": {
+			doc: `467..114..
+...*......
+..35..633.`,
+			topology: Masked(Rectangular, func(x, y int) bool {
+				return x == 3 && y == 1
+			}),
+		},
+		"masked number": {
+			doc: `467..114..
+...*......
+..35..633.`,
+			topology: Masked(Rectangular, func(x, y int) bool {
+				return y == 2
+			}),
+			wantParts: []int{467},
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				s := FromDocument(bytes.NewBufferString(tc.doc), WithTopology(tc.topology))
+				if diff := cmp.Diff(s.PartNumbers(), tc.wantParts); diff != "" {
+					t.Errorf("PartNumbers(): mismatch (-got,+want):\n%v", diff)
+				}
+				if diff := cmp.Diff(s.GearRatios(), tc.wantGears); diff != "" {
+					t.Errorf("GearRatios(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestMaskFromDocument(t *testing.T) {
+	mask, err := MaskFromDocument(bytes.NewBufferString("..#\n#"))
+	if err != nil {
+		t.Fatalf("MaskFromDocument(): unexpected error: %v", err)
+	}
+	for _, tc := range []struct {
+		x, y int
+		want bool
+	}{
+		{0, 0, false}, {2, 0, true}, {0, 1, true}, {1, 1, false}, {5, 5, false},
+	} {
+		if got := mask(tc.x, tc.y); got != tc.want {
+			t.Errorf("mask(%d, %d): got: %v want: %v", tc.x, tc.y, got, tc.want)
+		}
+	}
+	if _, err := MaskFromDocument(bytes.NewBufferString("..x")); err == nil {
+		t.Error("MaskFromDocument(): expected error for unexpected character")
+	}
+}
+
+func TestMaskedFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mask")
+	if err := os.WriteFile(path, []byte("..........\n...#......\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	top, err := MaskedFromFile(Rectangular, path)
+	if err != nil {
+		t.Fatalf("MaskedFromFile(): unexpected error: %v", err)
+	}
+	if _, _, ok := top.Resolve(3, 1, 10, 10); ok {
+		t.Error("Resolve(3, 1): masked position is not off-limits")
+	}
+	if _, _, ok := top.Resolve(4, 1, 10, 10); !ok {
+		t.Error("Resolve(4, 1): unmasked position is off-limits")
+	}
+	if _, err := MaskedFromFile(Rectangular, filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("MaskedFromFile(): expected error for missing file")
+	}
+}
+
+// TestMaskedSchematic runs a schematic through a mask read from a document, as
+// the --mask flag of stars five and six does.
+func TestMaskedSchematic(t *testing.T) {
+	type test struct {
+		mask      string
+		base      Topology
+		wantParts []int
+		wantGears []int
+	}
+
+	doc := `467..114..
+...*......
+..35..633.
+......#...
+617*......
+.....+.58.
+..592.....
+......755.
+...$.*....
+.664.598..`
+
+	for tn, tc := range map[string]test{
+		"unmasked": {
+			base:      Rectangular,
+			wantParts: []int{35, 467, 592, 598, 617, 633, 664, 755},
+			wantGears: []int{16345, 451490},
+		},
+		"gear masked": {
+			mask: `..........
+...#......`,
+			base:      Rectangular,
+			wantParts: []int{592, 598, 617, 633, 664, 755},
+			wantGears: []int{451490},
+		},
+		"number masked": {
+			mask: `..........
+..........
+..........
+..........
+..........
+..........
+..........
+......#...`,
+			base:      Rectangular,
+			wantParts: []int{35, 467, 592, 598, 617, 633, 664},
+			wantGears: []int{16345},
+		},
+		"masked toroidal": {
+			mask: `..........
+...#......`,
+			base:      Toroidal,
+			wantParts: []int{592, 598, 617, 633, 664, 755},
+			wantGears: []int{451490},
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				mask, err := MaskFromDocument(bytes.NewBufferString(tc.mask))
+				if err != nil {
+					t.Fatalf("MaskFromDocument(): unexpected error: %v", err)
+				}
+				s := FromDocument(bytes.NewBufferString(doc), WithTopology(Masked(tc.base, mask)))
+				if diff := cmp.Diff(s.PartNumbers(), tc.wantParts); diff != "" {
+					t.Errorf("PartNumbers(): mismatch (-got,+want):\n%v", diff)
+				}
+				if diff := cmp.Diff(s.GearRatios(), tc.wantGears); diff != "" {
+					t.Errorf("GearRatios(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestGridTopology(t *testing.T) {
+	g := GridFromDocument(bytes.NewBufferString("1...#"), WithTopology(Toroidal))
+	if !g.IsPartNumber(g.At(0, 0)) {
+		t.Error("IsPartNumber(): number touching a symbol across the edge is not a part number")
+	}
+	if diff := cmp.Diff(len(g.Inspect().Unattached), 0); diff != "" {
+		t.Errorf("Inspect(): Unattached mismatch (-got,+want):\n%v", diff)
+	}
+}
@@ -0,0 +1,157 @@
+// Package grid provides points and neighbourhoods on a two dimensional integer
+// grid, for puzzles laid out as rows of characters.
+package grid
+
+import "fmt"
+
+// Point on the grid. X increases to the east, and Y to the south, so that the
+// first character of a document is at 0, 0.
+type Point struct {
+	X, Y int
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
+}
+
+// Add the components of o to p.
+func (p Point) Add(o Point) Point {
+	return Point{p.X + o.X, p.Y + o.Y}
+}
+
+// Sub the components of o from p.
+func (p Point) Sub(o Point) Point {
+	return Point{p.X - o.X, p.Y - o.Y}
+}
+
+// Scale both components of p by k.
+func (p Point) Scale(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Manhattan distance between p and o.
+func (p Point) Manhattan(o Point) int {
+	return abs(p.X-o.X) + abs(p.Y-o.Y)
+}
+
+var (
+	North = Point{0, -1}
+	East  = Point{1, 0}
+	South = Point{0, 1}
+	West  = Point{-1, 0}
+
+	// Orthogonal directions, clockwise from North.
+	Orthogonal = [4]Point{North, East, South, West}
+	// Surrounding directions, clockwise from North, including diagonals.
+	Surrounding = [8]Point{
+		North, North.Add(East), East, South.Add(East),
+		South, South.Add(West), West, North.Add(West),
+	}
+)
+
+// Bounds of a grid with the given width and height, with its origin at 0, 0.
+type Bounds struct {
+	Width, Height int
+}
+
+// Contains is true if p lies within the bounds.
+func (b Bounds) Contains(p Point) bool {
+	return p.X >= 0 && p.X < b.Width && p.Y >= 0 && p.Y < b.Height
+}
+
+func (b Bounds) offsets(p Point, dirs []Point) (ret []Point) {
+	for _, d := range dirs {
+		if n := p.Add(d); b.Contains(n) {
+			ret = append(ret, n)
+		}
+	}
+	return
+}
+
+// Neighbours4 of p which lie within the bounds, clockwise from North.
+func (b Bounds) Neighbours4(p Point) []Point {
+	return b.offsets(p, Orthogonal[:])
+}
+
+// Neighbours8 of p which lie within the bounds, including diagonals, clockwise
+// from North.
+func (b Bounds) Neighbours8(p Point) []Point {
+	return b.offsets(p, Surrounding[:])
+}
+
+// Grid of runes, such as a puzzle input.
+type Grid struct {
+	Bounds
+	rows [][]rune
+}
+
+// New Grid from lines of text. Shorter lines are padded with fill so that the
+// grid is rectangular.
+func New(lines []string, fill rune) *Grid {
+	g := &Grid{rows: make([][]rune, len(lines))}
+	for y, l := range lines {
+		g.rows[y] = []rune(l)
+		g.Width = max(g.Width, len(g.rows[y]))
+	}
+	g.Height = len(lines)
+	for y, r := range g.rows {
+		for len(r) < g.Width {
+			r = append(r, fill)
+		}
+		g.rows[y] = r
+	}
+	return g
+}
+
+// At returns the rune at p, and whether p lies within the grid.
+func (g *Grid) At(p Point) (rune, bool) {
+	if !g.Contains(p) {
+		return 0, false
+	}
+	return g.rows[p.Y][p.X], true
+}
+
+// Set the rune at p, which must lie within the grid.
+func (g *Grid) Set(p Point, r rune) {
+	g.rows[p.Y][p.X] = r
+}
+
+// Find the first point, in reading order, at which r appears.
+func (g *Grid) Find(r rune) (Point, bool) {
+	for y, row := range g.rows {
+		for x, c := range row {
+			if c == r {
+				return Point{x, y}, true
+			}
+		}
+	}
+	return Point{}, false
+}
+
+// Passable returns a neighbour function yielding the orthogonal neighbours of a
+// point for which ok is true.
+func (g *Grid) Passable(ok func(r rune) bool) func(p Point) []Point {
+	return func(p Point) (ret []Point) {
+		for _, n := range g.Neighbours4(p) {
+			if ok(g.rows[n.Y][n.X]) {
+				ret = append(ret, n)
+			}
+		}
+		return
+	}
+}
+
+func (g *Grid) String() string {
+	var ret []rune
+	for _, row := range g.rows {
+		ret = append(append(ret, row...), '\n')
+	}
+	return string(ret)
+}
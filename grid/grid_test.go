@@ -0,0 +1,60 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNeighbours(t *testing.T) {
+	b := Bounds{3, 2}
+	type test struct {
+		got, want []Point
+	}
+
+	for tn, tc := range map[string]test{
+		"corner 4":  {b.Neighbours4(Point{0, 0}), []Point{{1, 0}, {0, 1}}},
+		"middle 4":  {b.Neighbours4(Point{1, 1}), []Point{{1, 0}, {2, 1}, {0, 1}}},
+		"corner 8":  {b.Neighbours8(Point{2, 0}), []Point{{2, 1}, {1, 1}, {1, 0}}},
+		"outside 4": {b.Neighbours4(Point{5, 5}), nil},
+		"middle 8":  {b.Neighbours8(Point{1, 0}), []Point{{2, 0}, {2, 1}, {1, 1}, {0, 1}, {0, 0}}},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				if diff := cmp.Diff(tc.got, tc.want); diff != "" {
+					t.Errorf("mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestManhattan(t *testing.T) {
+	if got := (Point{-1, 2}).Manhattan(Point{3, -1}); got != 7 {
+		t.Errorf("Manhattan(): got: %v want: 7", got)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g := New([]string{"#.S", "."}, '#')
+	if g.Bounds != (Bounds{3, 2}) {
+		t.Errorf("Bounds: got: %v want: {3 2}", g.Bounds)
+	}
+	if diff := cmp.Diff(g.String(), "#.S\n.##\n"); diff != "" {
+		t.Errorf("String(): mismatch (-got,+want):\n%v", diff)
+	}
+	if p, ok := g.Find('S'); !ok || p != (Point{2, 0}) {
+		t.Errorf("Find('S'): got: %v, %v want: (2,0), true", p, ok)
+	}
+	if _, ok := g.At(Point{3, 0}); ok {
+		t.Errorf("At(3,0): want out of bounds")
+	}
+	open := g.Passable(func(r rune) bool { return r != '#' })
+	if diff := cmp.Diff(open(Point{1, 0}), []Point{{2, 0}}); diff != "" {
+		t.Errorf("Passable(): mismatch (-got,+want):\n%v", diff)
+	}
+	g.Set(Point{1, 1}, '.')
+	if diff := cmp.Diff(open(Point{1, 0}), []Point{{2, 0}, {1, 1}}); diff != "" {
+		t.Errorf("Passable() after Set(): mismatch (-got,+want):\n%v", diff)
+	}
+}
@@ -0,0 +1,221 @@
+// Package interval models half-open ranges of integers, and sets of them.
+package interval
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Interval is the half-open range of integers [Start, End). An interval whose
+// End is not after its Start is empty.
+type Interval struct {
+	Start, End int
+}
+
+// Of is the interval of length n beginning at start.
+func Of(start, n int) Interval {
+	return Interval{start, start + n}
+}
+
+func (iv Interval) String() string {
+	return fmt.Sprintf("[%d,%d)", iv.Start, iv.End)
+}
+
+// Empty is true if the interval contains no integers.
+func (iv Interval) Empty() bool {
+	return iv.End <= iv.Start
+}
+
+// Len is the number of integers in the interval.
+func (iv Interval) Len() int {
+	if iv.Empty() {
+		return 0
+	}
+	return iv.End - iv.Start
+}
+
+// Contains is true if v lies within the interval.
+func (iv Interval) Contains(v int) bool {
+	return iv.Start <= v && v < iv.End
+}
+
+// Overlaps is true if the intervals share at least one integer.
+func (iv Interval) Overlaps(o Interval) bool {
+	return !iv.Intersect(o).Empty()
+}
+
+// Intersect is the interval of integers in both iv and o, which may be empty.
+func (iv Interval) Intersect(o Interval) Interval {
+	return Interval{max(iv.Start, o.Start), min(iv.End, o.End)}
+}
+
+// Shift the interval by d.
+func (iv Interval) Shift(d int) Interval {
+	return Interval{iv.Start + d, iv.End + d}
+}
+
+// Split the interval by another into the parts before, inside and after it.
+// Any of the parts may be empty.
+func (iv Interval) Split(by Interval) (before, inside, after Interval) {
+	before = Interval{iv.Start, min(iv.End, by.Start)}
+	inside = iv.Intersect(by)
+	after = Interval{max(iv.Start, by.End), iv.End}
+	return
+}
+
+// Subtract o from the interval, leaving up to two non-empty intervals.
+func (iv Interval) Subtract(o Interval) (ret []Interval) {
+	if !iv.Overlaps(o) {
+		if !iv.Empty() {
+			ret = append(ret, iv)
+		}
+		return
+	}
+	before, _, after := iv.Split(o)
+	for _, p := range []Interval{before, after} {
+		if !p.Empty() {
+			ret = append(ret, p)
+		}
+	}
+	return
+}
+
+// Set of integers, stored as the fewest intervals which cover them. The zero
+// value is an empty Set ready to use.
+type Set struct {
+	// intervals are non-empty, sorted, and neither overlap nor touch.
+	intervals []Interval
+}
+
+// NewSet of the integers covered by any of the intervals.
+func NewSet(ivs ...Interval) *Set {
+	s := &Set{}
+	s.Add(ivs...)
+	return s
+}
+
+// normalize sorts and merges intervals, dropping empty ones.
+func normalize(ivs []Interval) (ret []Interval) {
+	sort.Slice(ivs, func(i, j int) bool { return ivs[i].Start < ivs[j].Start })
+	for _, iv := range ivs {
+		if iv.Empty() {
+			continue
+		}
+		if n := len(ret); n > 0 && iv.Start <= ret[n-1].End {
+			ret[n-1].End = max(ret[n-1].End, iv.End)
+			continue
+		}
+		ret = append(ret, iv)
+	}
+	return
+}
+
+// Add the integers covered by the intervals to the set.
+func (s *Set) Add(ivs ...Interval) {
+	s.intervals = normalize(append(append([]Interval(nil), s.intervals...), ivs...))
+}
+
+// Intervals covering the set, sorted, neither overlapping nor touching.
+func (s *Set) Intervals() []Interval {
+	return append([]Interval(nil), s.intervals...)
+}
+
+// Len is the number of integers in the set.
+func (s *Set) Len() (n int) {
+	for _, iv := range s.intervals {
+		n += iv.Len()
+	}
+	return
+}
+
+// Empty is true if the set contains no integers.
+func (s *Set) Empty() bool {
+	return len(s.intervals) == 0
+}
+
+// Min is the smallest integer in the set. It is only valid if ok is true.
+func (s *Set) Min() (v int, ok bool) {
+	if s.Empty() {
+		return 0, false
+	}
+	return s.intervals[0].Start, true
+}
+
+// Contains is true if v is in the set.
+func (s *Set) Contains(v int) bool {
+	i := sort.Search(len(s.intervals), func(i int) bool { return s.intervals[i].End > v })
+	return i < len(s.intervals) && s.intervals[i].Contains(v)
+}
+
+// Union is a new Set of the integers in either s or o.
+func (s *Set) Union(o *Set) *Set {
+	return &Set{normalize(append(s.Intervals(), o.intervals...))}
+}
+
+// Intersect is a new Set of the integers in both s and o.
+func (s *Set) Intersect(o *Set) *Set {
+	ret := &Set{}
+	for i, j := 0, 0; i < len(s.intervals) && j < len(o.intervals); {
+		l, r := s.intervals[i], o.intervals[j]
+		if iv := l.Intersect(r); !iv.Empty() {
+			ret.intervals = append(ret.intervals, iv)
+		}
+		// Whichever ends first cannot overlap anything further in the other.
+		if l.End < r.End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return ret
+}
+
+// Subtract is a new Set of the integers in s but not in o.
+func (s *Set) Subtract(o *Set) *Set {
+	ret := &Set{}
+	j := 0
+	for _, iv := range s.intervals {
+		// Skip the intervals of o which end before this one begins; they
+		// cannot overlap it or any which follow.
+		for j < len(o.intervals) && o.intervals[j].End <= iv.Start {
+			j++
+		}
+		k := j
+		for ; k < len(o.intervals) && o.intervals[k].Start < iv.End; k++ {
+			before, _, after := iv.Split(o.intervals[k])
+			if !before.Empty() {
+				ret.intervals = append(ret.intervals, before)
+			}
+			iv = after
+		}
+		if !iv.Empty() {
+			ret.intervals = append(ret.intervals, iv)
+		}
+	}
+	return ret
+}
+
+// Split the set by an interval into a new Set of the integers inside it and
+// another of those outside it.
+func (s *Set) Split(by Interval) (inside, outside *Set) {
+	bs := NewSet(by)
+	return s.Intersect(bs), s.Subtract(bs)
+}
+
+// Shift is a new Set with every integer in s moved by d.
+func (s *Set) Shift(d int) *Set {
+	ret := &Set{make([]Interval, len(s.intervals))}
+	for i, iv := range s.intervals {
+		ret.intervals[i] = iv.Shift(d)
+	}
+	return ret
+}
+
+func (s *Set) String() string {
+	parts := make([]string, len(s.intervals))
+	for i, iv := range s.intervals {
+		parts[i] = iv.String()
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
@@ -0,0 +1,169 @@
+package interval
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIntervalSplit(t *testing.T) {
+	type test struct {
+		iv, by                Interval
+		before, inside, after Interval
+	}
+	for tn, tc := range map[string]test{
+		"inside":   {Interval{0, 10}, Interval{3, 5}, Interval{0, 3}, Interval{3, 5}, Interval{5, 10}},
+		"covering": {Interval{3, 5}, Interval{0, 10}, Interval{3, 0}, Interval{3, 5}, Interval{10, 5}},
+		"left":     {Interval{0, 10}, Interval{-5, 5}, Interval{0, -5}, Interval{0, 5}, Interval{5, 10}},
+		"right":    {Interval{0, 10}, Interval{5, 15}, Interval{0, 5}, Interval{5, 10}, Interval{15, 10}},
+		"disjoint": {Interval{0, 10}, Interval{20, 30}, Interval{0, 10}, Interval{20, 10}, Interval{30, 10}},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				before, inside, after := tc.iv.Split(tc.by)
+				if before.Len() != tc.before.Len() || inside.Len() != tc.inside.Len() || after.Len() != tc.after.Len() {
+					t.Errorf("%v.Split(%v): got: %v %v %v want: %v %v %v",
+						tc.iv, tc.by, before, inside, after, tc.before, tc.inside, tc.after)
+				}
+				for _, p := range []struct{ got, want Interval }{{before, tc.before}, {inside, tc.inside}, {after, tc.after}} {
+					if !p.want.Empty() && p.got != p.want {
+						t.Errorf("%v.Split(%v): got part: %v want: %v", tc.iv, tc.by, p.got, p.want)
+					}
+				}
+				if n := before.Len() + inside.Len() + after.Len(); n != tc.iv.Len() {
+					t.Errorf("%v.Split(%v): parts cover %d integers, want %d", tc.iv, tc.by, n, tc.iv.Len())
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestIntervalSubtract(t *testing.T) {
+	type test struct {
+		iv, o Interval
+		want  []Interval
+	}
+
+	for tn, tc := range map[string]test{
+		"middle":   {Interval{0, 10}, Interval{3, 5}, []Interval{{0, 3}, {5, 10}}},
+		"all":      {Interval{3, 5}, Interval{0, 10}, nil},
+		"disjoint": {Interval{0, 3}, Interval{3, 5}, []Interval{{0, 3}}},
+		"empty":    {Interval{3, 3}, Interval{0, 1}, nil},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				if diff := cmp.Diff(tc.iv.Subtract(tc.o), tc.want); diff != "" {
+					t.Errorf("%v.Subtract(%v): mismatch (-got,+want):\n%v", tc.iv, tc.o, diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestNewSet(t *testing.T) {
+	s := NewSet(Interval{5, 8}, Interval{0, 2}, Interval{7, 10}, Interval{2, 3}, Interval{4, 4})
+	want := []Interval{{0, 3}, {5, 10}}
+	if diff := cmp.Diff(s.Intervals(), want); diff != "" {
+		t.Errorf("NewSet(): mismatch (-got,+want):\n%v", diff)
+	}
+	if got := s.Len(); got != 8 {
+		t.Errorf("Len(): got: %v want: 8", got)
+	}
+	if got := s.String(); got != "{[0,3) [5,10)}" {
+		t.Errorf("String(): got: %q", got)
+	}
+	for v, want := range map[int]bool{-1: false, 0: true, 2: true, 3: false, 4: false, 5: true, 9: true, 10: false} {
+		if got := s.Contains(v); got != want {
+			t.Errorf("Contains(%d): got: %v want: %v", v, got, want)
+		}
+	}
+	var zero Set
+	if !zero.Empty() || zero.Len() != 0 || zero.Contains(0) {
+		t.Errorf("zero Set is not empty")
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	l := NewSet(Interval{0, 5}, Interval{10, 15}, Interval{20, 25})
+	r := NewSet(Interval{3, 12}, Interval{14, 21}, Interval{30, 31})
+	type test struct {
+		got  *Set
+		want []Interval
+	}
+
+	for tn, tc := range map[string]test{
+		"union":     {l.Union(r), []Interval{{0, 25}, {30, 31}}},
+		"intersect": {l.Intersect(r), []Interval{{3, 5}, {10, 12}, {14, 15}, {20, 21}}},
+		"subtract":  {l.Subtract(r), []Interval{{0, 3}, {12, 14}, {21, 25}}},
+		"reversed":  {r.Subtract(l), []Interval{{5, 10}, {15, 20}, {30, 31}}},
+		"shift":     {l.Shift(-10), []Interval{{-10, -5}, {0, 5}, {10, 15}}},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				if diff := cmp.Diff(tc.got.Intervals(), tc.want); diff != "" {
+					t.Errorf("mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+
+	inside, outside := l.Split(Interval{4, 21})
+	if diff := cmp.Diff(inside.Intervals(), []Interval{{4, 5}, {10, 15}, {20, 21}}); diff != "" {
+		t.Errorf("Split() inside: mismatch (-got,+want):\n%v", diff)
+	}
+	if diff := cmp.Diff(outside.Intervals(), []Interval{{0, 4}, {21, 25}}); diff != "" {
+		t.Errorf("Split() outside: mismatch (-got,+want):\n%v", diff)
+	}
+}
+
+// randomSet returns a Set of random intervals within [0, 100), along with the
+// integers it should contain.
+func randomSet(r *rand.Rand) (*Set, map[int]bool) {
+	s := &Set{}
+	members := make(map[int]bool)
+	for i := r.Intn(6); i > 0; i-- {
+		start := r.Intn(100)
+		iv := Interval{start, start + r.Intn(20)}
+		s.Add(iv)
+		for v := iv.Start; v < iv.End; v++ {
+			members[v] = true
+		}
+	}
+	return s, members
+}
+
+func TestSetOperationsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(2023))
+	for i := 0; i < 500; i++ {
+		l, lm := randomSet(r)
+		o, om := randomSet(r)
+		for name, tc := range map[string]struct {
+			got  *Set
+			want func(v int) bool
+		}{
+			"union":     {l.Union(o), func(v int) bool { return lm[v] || om[v] }},
+			"intersect": {l.Intersect(o), func(v int) bool { return lm[v] && om[v] }},
+			"subtract":  {l.Subtract(o), func(v int) bool { return lm[v] && !om[v] }},
+		} {
+			var n int
+			for v := -1; v < 121; v++ {
+				if want := tc.want(v); tc.got.Contains(v) != want {
+					t.Fatalf("%v %s %v: Contains(%d) want: %v", l, name, o, v, want)
+				} else if want {
+					n++
+				}
+			}
+			if tc.got.Len() != n {
+				t.Fatalf("%v %s %v: Len() got: %d want: %d", l, name, o, tc.got.Len(), n)
+			}
+			// Results must be normalized: sorted, neither overlapping nor touching.
+			ivs := tc.got.Intervals()
+			for j := 1; j < len(ivs); j++ {
+				if ivs[j].Start <= ivs[j-1].End {
+					t.Fatalf("%v %s %v: got unnormalized %v", l, name, o, tc.got)
+				}
+			}
+		}
+	}
+}
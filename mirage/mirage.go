@@ -0,0 +1,221 @@
+// Package mirage extrapolates the sequences of readings used by stars seventeen
+// and eighteen.
+// See: https://adventofcode.com/2023/day/9
+package mirage
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// Sequence of readings, taken at positions 0, 1, 2, and so on. Values are of
+// arbitrary precision, so extrapolation is exact however large they grow.
+type Sequence []*big.Int
+
+var (
+	// ErrInvalidSequence is returned when a sequence cannot be parsed.
+	ErrInvalidSequence = errors.New("invalid sequence")
+	// ErrNeverZero is returned when the differences of a sequence never reach
+	// a row of all zeros, so it cannot be extrapolated.
+	ErrNeverZero = errors.New("differences never reach zero")
+)
+
+// Table of differences of a sequence. The first row is the sequence itself, and
+// each following row holds the differences between adjacent values of the row
+// before it. The last row is all zeros.
+type Table []Sequence
+
+func allZero(s Sequence) bool {
+	for _, v := range s {
+		if v.Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Table of differences of the sequence. Returns ErrNeverZero if the
+// differences run out before reaching a row of all zeros.
+func (s Sequence) Table() (Table, error) {
+	if len(s) == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidSequence)
+	}
+	var t Table
+	for row := s; ; {
+		t = append(t, row)
+		if allZero(row) {
+			return t, nil
+		}
+		if len(row) == 1 {
+			return nil, fmt.Errorf("%w: %d rows deep", ErrNeverZero, len(t))
+		}
+		next := make(Sequence, len(row)-1)
+		for i := range next {
+			next[i] = new(big.Int).Sub(row[i+1], row[i])
+		}
+		row = next
+	}
+}
+
+// Next value of the table's sequence, found by extending each row by one value
+// at its end, from the bottom up.
+func (t Table) Next() *big.Int {
+	v := new(big.Int)
+	for i := len(t) - 1; i >= 0; i-- {
+		v.Add(v, t[i][len(t[i])-1])
+	}
+	return v
+}
+
+// Prev is the value of the table's sequence before its first, found by
+// extending each row by one value at its start, from the bottom up.
+func (t Table) Prev() *big.Int {
+	v := new(big.Int)
+	for i := len(t) - 1; i >= 0; i-- {
+		v.Sub(t[i][0], v)
+	}
+	return v
+}
+
+// Method by which a sequence is extrapolated.
+type Method int
+
+const (
+	// DifferenceTable extrapolates by extending the table of differences.
+	DifferenceTable Method = iota
+	// Lagrange extrapolates by evaluating the Lagrange polynomial through
+	// every value of the sequence. A sequence whose differences reach zero
+	// after d rows is a polynomial of degree less than d, which is the one
+	// polynomial through its values, so both methods agree.
+	Lagrange
+)
+
+var methodNames = [...]string{
+	DifferenceTable: "differences",
+	Lagrange:        "lagrange",
+}
+
+func (m Method) String() string {
+	if m < 0 || int(m) >= len(methodNames) {
+		return fmt.Sprintf("Method(%d)", int(m))
+	}
+	return methodNames[m]
+}
+
+// Next value of the sequence, extrapolated using the method.
+func (s Sequence) Next(m Method) (*big.Int, error) {
+	return s.extrapolate(len(s), m)
+}
+
+// Prev is the value of the sequence before its first, extrapolated using the
+// method.
+func (s Sequence) Prev(m Method) (*big.Int, error) {
+	return s.extrapolate(-1, m)
+}
+
+// extrapolate the value at position x, which must be either -1 or len(s).
+// Either method first checks that the differences reach zero.
+func (s Sequence) extrapolate(x int, m Method) (*big.Int, error) {
+	t, err := s.Table()
+	if err != nil {
+		return nil, err
+	}
+	switch m {
+	case DifferenceTable:
+		if x < 0 {
+			return t.Prev(), nil
+		}
+		return t.Next(), nil
+	case Lagrange:
+		return s.lagrange(x)
+	}
+	return nil, fmt.Errorf("unknown method %v", m)
+}
+
+// lagrange evaluates the polynomial through the sequence's values at x:
+//
+//	sum over i of s[i] * product over j != i of (x - j) / (i - j)
+//
+// The arithmetic is over exact rationals, and the result is an integer as the
+// sequence's positions are.
+func (s Sequence) lagrange(x int) (*big.Int, error) {
+	sum := new(big.Rat)
+	for i, y := range s {
+		term := new(big.Rat).SetInt(y)
+		for j := range s {
+			if j != i {
+				term.Mul(term, big.NewRat(int64(x-j), int64(i-j)))
+			}
+		}
+		sum.Add(sum, term)
+	}
+	if !sum.IsInt() {
+		return nil, fmt.Errorf("lagrange polynomial at %d is not an integer: %v", x, sum)
+	}
+	return new(big.Int).Set(sum.Num()), nil
+}
+
+// SumNext is the sum of the next value of every sequence.
+func SumNext(seqs []Sequence, m Method) (*big.Int, error) {
+	return sum(seqs, m, Sequence.Next)
+}
+
+// SumPrev is the sum of the value before the first of every sequence.
+func SumPrev(seqs []Sequence, m Method) (*big.Int, error) {
+	return sum(seqs, m, Sequence.Prev)
+}
+
+func sum(seqs []Sequence, m Method, f func(Sequence, Method) (*big.Int, error)) (*big.Int, error) {
+	total := new(big.Int)
+	for i, s := range seqs {
+		v, err := f(s, m)
+		if err != nil {
+			return nil, fmt.Errorf("sequence %d: %w", i+1, err)
+		}
+		total.Add(total, v)
+	}
+	return total, nil
+}
+
+// Parse a sequence of space-separated integers.
+func Parse(s string) (Sequence, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidSequence)
+	}
+	seq := make(Sequence, len(fields))
+	for i, f := range fields {
+		v, ok := new(big.Int).SetString(f, 10)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not an integer", ErrInvalidSequence, f)
+		}
+		seq[i] = v
+	}
+	return seq, nil
+}
+
+// FromDocument parses sequences, one per line.
+func FromDocument(doc io.Reader) (seqs []Sequence, err error) {
+	s := bufio.NewScanner(doc)
+	for line := 1; s.Scan(); line++ {
+		if strings.TrimSpace(s.Text()) == "" {
+			continue
+		}
+		seq, err := Parse(s.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		seqs = append(seqs, seq)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(seqs) == 0 {
+		return nil, fmt.Errorf("%w: no sequences", ErrInvalidSequence)
+	}
+	return
+}
@@ -0,0 +1,145 @@
+package mirage
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+const example = `0 3 6 9 12 15
+1 3 6 10 15 21
+10 13 16 21 30 45`
+
+func seq(t *testing.T, s string) Sequence {
+	t.Helper()
+	v, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q): unexpected error: %v", s, err)
+	}
+	return v
+}
+
+func TestExtrapolate(t *testing.T) {
+	type test struct {
+		seq        string
+		next, prev string
+	}
+
+	for tn, tc := range map[string]test{
+		"linear":         {"0 3 6 9 12 15", "18", "-3"},
+		"triangular":     {"1 3 6 10 15 21", "28", "0"},
+		"cubic":          {"10 13 16 21 30 45", "68", "5"},
+		"all zeros":      {"0 0 0", "0", "0"},
+		"constant":       {"7 7", "7", "7"},
+		"negative":       {"-1 -4 -9 -16", "-25", "0"},
+		"beyond int64":   {"9223372036854775807 18446744073709551614 27670116110564327421", "36893488147419103228", "0"},
+		"single zero":    {"0", "0", "0"},
+		"decreasing sqr": {"16 9 4 1 0", "1", "25"},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				s := seq(t, tc.seq)
+				for _, m := range []Method{DifferenceTable, Lagrange} {
+					next, err := s.Next(m)
+					if err != nil {
+						t.Fatalf("Next(%v): unexpected error: %v", m, err)
+					}
+					if next.String() != tc.next {
+						t.Errorf("Next(%v): got: %v want: %v", m, next, tc.next)
+					}
+					prev, err := s.Prev(m)
+					if err != nil {
+						t.Fatalf("Prev(%v): unexpected error: %v", m, err)
+					}
+					if prev.String() != tc.prev {
+						t.Errorf("Prev(%v): got: %v want: %v", m, prev, tc.prev)
+					}
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+// TestMethodsAgree cross-checks the difference table against Lagrange
+// interpolation on random polynomials.
+func TestMethodsAgree(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	for n := 0; n < 200; n++ {
+		coeffs := make([]int64, 1+r.Intn(6))
+		for i := range coeffs {
+			coeffs[i] = r.Int63n(2001) - 1000
+		}
+		s := make(Sequence, len(coeffs)+1+r.Intn(5))
+		for x := range s {
+			v := new(big.Int)
+			for i := len(coeffs) - 1; i >= 0; i-- {
+				v.Mul(v, big.NewInt(int64(x)))
+				v.Add(v, big.NewInt(coeffs[i]))
+			}
+			s[x] = v
+		}
+		for name, f := range map[string]func(Sequence, Method) (*big.Int, error){
+			"Next": Sequence.Next,
+			"Prev": Sequence.Prev,
+		} {
+			a, err := f(s, DifferenceTable)
+			if err != nil {
+				t.Fatalf("%s(%v) of %v: unexpected error: %v", name, DifferenceTable, s, err)
+			}
+			b, err := f(s, Lagrange)
+			if err != nil {
+				t.Fatalf("%s(%v) of %v: unexpected error: %v", name, Lagrange, s, err)
+			}
+			if a.Cmp(b) != 0 {
+				t.Errorf("%s() of %v: %v got: %v, %v got: %v", name, s, DifferenceTable, a, Lagrange, b)
+			}
+		}
+	}
+}
+
+func TestNeverZero(t *testing.T) {
+	for _, s := range []string{"5", "1 2 4 8", "0 0 1"} {
+		for _, m := range []Method{DifferenceTable, Lagrange} {
+			if _, err := seq(t, s).Next(m); !errors.Is(err, ErrNeverZero) {
+				t.Errorf("Next(%v) of %q: got error: %v want: %v", m, s, err, ErrNeverZero)
+			}
+		}
+	}
+}
+
+func TestSums(t *testing.T) {
+	seqs, err := FromDocument(bytes.NewBufferString(example))
+	if err != nil {
+		t.Fatalf("FromDocument(): unexpected error: %v", err)
+	}
+	for _, m := range []Method{DifferenceTable, Lagrange} {
+		if got, err := SumNext(seqs, m); err != nil || got.Int64() != 114 {
+			t.Errorf("SumNext(%v): got: %v, %v want: 114", m, got, err)
+		}
+		if got, err := SumPrev(seqs, m); err != nil || got.Int64() != 2 {
+			t.Errorf("SumPrev(%v): got: %v, %v want: 2", m, got, err)
+		}
+	}
+	seqs = append(seqs, seq(t, "1 2 4 8"))
+	if _, err := SumNext(seqs, DifferenceTable); !errors.Is(err, ErrNeverZero) {
+		t.Errorf("SumNext() with diverging sequence: got error: %v want: %v", err, ErrNeverZero)
+	}
+}
+
+func TestFromDocumentErrors(t *testing.T) {
+	for tn, doc := range map[string]string{
+		"empty":       "",
+		"not integer": "1 2 x",
+		"decimal":     "1 2.5 3",
+	} {
+		func(t *testing.T, tn, doc string) {
+			t.Run(tn, func(t *testing.T) {
+				if _, err := FromDocument(bytes.NewBufferString(doc)); !errors.Is(err, ErrInvalidSequence) {
+					t.Errorf("FromDocument(): got error: %v want: %v", err, ErrInvalidSequence)
+				}
+			})
+		}(t, tn, doc)
+	}
+}
@@ -0,0 +1,63 @@
+package pipes
+
+import (
+	"fmt"
+
+	"github.com/cfunkhouser/aoc2023/grid"
+)
+
+// Inside returns the tiles enclosed by the loop, in reading order, found by
+// scanning each row. A ray cast westward from a tile crosses the loop once for
+// each tile of it passed which connects to the north, so a tile not on the loop
+// is enclosed if it has passed an odd number of them.
+func (l *Loop) Inside() (ret []grid.Point) {
+	for y := 0; y < l.maze.Height; y++ {
+		inside := false
+		for x := 0; x < l.maze.Width; x++ {
+			p := grid.Point{X: x, Y: y}
+			switch {
+			case l.on[p]:
+				if connects(l.Shape(p), grid.North) {
+					inside = !inside
+				}
+			case inside:
+				ret = append(ret, p)
+			}
+		}
+	}
+	return
+}
+
+// ScanlineArea is the number of tiles enclosed by the loop, found by scanning
+// each row. See Inside.
+func (l *Loop) ScanlineArea() int {
+	return len(l.Inside())
+}
+
+// PickArea is the number of tiles enclosed by the loop, found from the area of
+// the polygon through the centres of its tiles. The shoelace formula gives the
+// polygon's area A, and Pick's theorem relates it to the i whole points inside
+// it and the b on its boundary:
+//
+//	A = i + b/2 - 1
+//
+// Every tile of the loop is a boundary point, so i = A - b/2 + 1.
+func (l *Loop) PickArea() int {
+	var twice int
+	for i, p := range l.Path {
+		q := l.Path[(i+1)%len(l.Path)]
+		twice += p.X*q.Y - q.X*p.Y
+	}
+	twice = max(twice, -twice)
+	return (twice-len(l.Path))/2 + 1
+}
+
+// Area enclosed by the loop, counted both by scanline and by Pick's theorem.
+// Returns an error if the two disagree.
+func (l *Loop) Area() (int, error) {
+	scan, pick := l.ScanlineArea(), l.PickArea()
+	if scan != pick {
+		return 0, fmt.Errorf("enclosed area mismatch: scanline found %d, Pick's theorem %d", scan, pick)
+	}
+	return scan, nil
+}
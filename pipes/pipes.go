@@ -0,0 +1,177 @@
+// Package pipes finds the loop of pipe in the maze used by stars nineteen and
+// twenty, and the area it encloses.
+// See: https://adventofcode.com/2023/day/10
+package pipes
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cfunkhouser/aoc2023/grid"
+)
+
+// Shapes of pipe, in the order they are tried for the start tile.
+const Shapes = "|-LJ7F"
+
+// connections of each shape of pipe.
+var connections = map[rune][2]grid.Point{
+	'|': {grid.North, grid.South},
+	'-': {grid.East, grid.West},
+	'L': {grid.North, grid.East},
+	'J': {grid.North, grid.West},
+	'7': {grid.South, grid.West},
+	'F': {grid.East, grid.South},
+}
+
+// connects is true if a pipe of the given shape leads in direction d.
+func connects(shape rune, d grid.Point) bool {
+	c, ok := connections[shape]
+	return ok && (c[0] == d || c[1] == d)
+}
+
+var (
+	// ErrInvalidMaze is returned when a maze cannot be parsed, or its start
+	// tile is ambiguous.
+	ErrInvalidMaze = errors.New("invalid maze")
+	// ErrNoLoop is returned when no loop of pipe passes through the start.
+	ErrNoLoop = errors.New("no loop through start")
+)
+
+// Maze of pipes, with an animal somewhere on a loop of them.
+type Maze struct {
+	*grid.Grid
+	// Start is where the animal is, marked S.
+	Start grid.Point
+}
+
+// Loop of pipe through the start of a maze.
+type Loop struct {
+	// Path of tiles around the loop, beginning at the start and following
+	// the first connection of StartShape.
+	Path []grid.Point
+	// StartShape is the shape of pipe inferred under the start tile.
+	StartShape rune
+
+	maze *Maze
+	on   map[grid.Point]bool
+}
+
+// Len of the loop, in tiles.
+func (l *Loop) Len() int {
+	return len(l.Path)
+}
+
+// Farthest distance along the loop from the start, in either direction.
+func (l *Loop) Farthest() int {
+	return len(l.Path) / 2
+}
+
+// Contains is true if p is on the loop.
+func (l *Loop) Contains(p grid.Point) bool {
+	return l.on[p]
+}
+
+// Shape of the pipe at p, with the start tile replaced by its inferred shape.
+func (l *Loop) Shape(p grid.Point) rune {
+	if p == l.maze.Start {
+		return l.StartShape
+	}
+	r, _ := l.maze.At(p)
+	return r
+}
+
+// walk the pipes from the start, as if its tile were the given shape, and
+// return the path if it leads back to the start.
+func (m *Maze) walk(shape rune) ([]grid.Point, bool) {
+	path := []grid.Point{m.Start}
+	d := connections[shape][0]
+	for p := m.Start.Add(d); p != m.Start; p = p.Add(d) {
+		r, ok := m.At(p)
+		back := d.Scale(-1)
+		if !ok || !connects(r, back) || len(path) > m.Width*m.Height {
+			return nil, false
+		}
+		path = append(path, p)
+		if c := connections[r]; c[0] == back {
+			d = c[1]
+		} else {
+			d = c[0]
+		}
+	}
+	// The walk returned to the start, so make sure it arrived by the shape's
+	// other connection.
+	return path, connects(shape, d.Scale(-1))
+}
+
+// Loop through the start. The shape of pipe under the start tile is inferred
+// by trying each shape whose connections are both met by a neighbouring pipe,
+// and keeping the one which forms a loop. Returns ErrNoLoop if none does, or
+// ErrInvalidMaze if more than one does.
+func (m *Maze) Loop() (*Loop, error) {
+	var found []*Loop
+	for _, shape := range Shapes {
+		if path, ok := m.walk(shape); ok {
+			found = append(found, &Loop{Path: path, StartShape: shape, maze: m})
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("%w at %v", ErrNoLoop, m.Start)
+	case 1:
+	default:
+		var shapes []rune
+		for _, l := range found {
+			shapes = append(shapes, l.StartShape)
+		}
+		return nil, fmt.Errorf("%w: start at %v could be any of %q", ErrInvalidMaze, m.Start, string(shapes))
+	}
+	l := found[0]
+	l.on = make(map[grid.Point]bool, len(l.Path))
+	for _, p := range l.Path {
+		l.on[p] = true
+	}
+	return l, nil
+}
+
+// FromDocument parses a maze, which must contain exactly one start tile. Blank
+// lines before and after the maze are ignored, but not within it, where they
+// would shift the rows which follow.
+func FromDocument(doc io.Reader) (*Maze, error) {
+	var lines []string
+	var starts, blank int
+	m := &Maze{}
+	s := bufio.NewScanner(doc)
+	for line := 1; s.Scan(); line++ {
+		l := strings.TrimSpace(s.Text())
+		switch {
+		case l == "":
+			if len(lines) > 0 && blank == 0 {
+				blank = line
+			}
+			continue
+		case blank != 0:
+			return nil, fmt.Errorf("line %d: %w: blank line within maze", blank, ErrInvalidMaze)
+		}
+		for x, r := range []rune(l) {
+			switch {
+			case r == 'S':
+				m.Start = grid.Point{X: x, Y: len(lines)}
+				starts++
+			case r != '.' && !strings.ContainsRune(Shapes, r):
+				return nil, fmt.Errorf("line %d: %w: unknown tile %q", line, ErrInvalidMaze, r)
+			}
+		}
+		lines = append(lines, l)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if starts != 1 {
+		return nil, fmt.Errorf("%w: %d start tiles, want 1", ErrInvalidMaze, starts)
+	}
+	m.Grid = grid.New(lines, '.')
+	return m, nil
+}
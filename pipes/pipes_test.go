@@ -0,0 +1,182 @@
+package pipes
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+const (
+	simple = `-L|F7
+7S-7|
+L|7||
+-L-J|
+L|-JF`
+
+	tangled = `7-F7-
+.FJ|7
+SJLL7
+|F--J
+LJ.LJ`
+
+	enclosed = `...........
+.S-------7.
+.|F-----7|.
+.||.....||.
+.||.....||.
+.|L-7.F-J|.
+.|..|.|..|.
+.L--J.L--J.
+...........`
+
+	squeezed = `..........
+.S------7.
+.|F----7|.
+.||....||.
+.||....||.
+.|L-7F-J|.
+.|..||..|.
+.L--JL--J.
+..........`
+
+	larger = `.F----7F7F7F7F-7....
+.|F--7||||||||FJ....
+.||.FJ||||||||L7....
+FJL7L7LJLJ||LJ.L-7..
+L--J.L7...LJS7F-7L7.
+....F-J..F7FJ|L7L7L7
+....L7.F7||L7|.L7L7|
+.....|FJLJ|FJ|F7|.LJ
+....FJL-7.||.||||...
+....L---J.LJ.LJLJ...`
+
+	junk = `FF7FSF7F7F7F7F7F---7
+L|LJ||||||||||||F--J
+FL-7LJLJ||||||LJL-77
+F--JF--7||LJLJ7F7FJ-
+L---JF-JLJ.||-FJLJJ7
+|F|F-JF---7F7-L7L|7|
+|FFJF7L7F-JF7|JL---7
+7-L-JL7||F7|L7F-7F7|
+L.L7LFJ|||||FJL7||LJ
+L7JLJL-JLJLJL--JLJ.L`
+)
+
+func loop(t *testing.T, doc string) *Loop {
+	t.Helper()
+	m, err := FromDocument(bytes.NewBufferString(doc))
+	if err != nil {
+		t.Fatalf("FromDocument(): unexpected error: %v", err)
+	}
+	l, err := m.Loop()
+	if err != nil {
+		t.Fatalf("Loop(): unexpected error: %v", err)
+	}
+	return l
+}
+
+func TestLoop(t *testing.T) {
+	type test struct {
+		doc      string
+		shape    rune
+		farthest int
+		area     int
+	}
+
+	for tn, tc := range map[string]test{
+		"simple":   {simple, 'F', 4, 1},
+		"tangled":  {tangled, 'F', 8, 1},
+		"enclosed": {enclosed, 'F', 23, 4},
+		"squeezed": {squeezed, 'F', 22, 4},
+		"larger":   {larger, 'F', 70, 8},
+		"junk":     {junk, '7', 80, 10},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				l := loop(t, tc.doc)
+				if l.StartShape != tc.shape {
+					t.Errorf("StartShape: got: %q want: %q", l.StartShape, tc.shape)
+				}
+				if got := l.Farthest(); got != tc.farthest {
+					t.Errorf("Farthest(): got: %d want: %d", got, tc.farthest)
+				}
+				if got := l.ScanlineArea(); got != tc.area {
+					t.Errorf("ScanlineArea(): got: %d want: %d", got, tc.area)
+				}
+				if got := l.PickArea(); got != tc.area {
+					t.Errorf("PickArea(): got: %d want: %d", got, tc.area)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestFromDocumentSurroundingBlankLines(t *testing.T) {
+	if got := loop(t, "\n\n"+simple+"\n\n").Farthest(); got != 4 {
+		t.Errorf("Farthest(): got: %d want: 4", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	var buf bytes.Buffer
+	if err := loop(t, simple).Render(&buf); err != nil {
+		t.Fatalf("Render(): unexpected error: %v", err)
+	}
+	want := "     \n" +
+		" S─┐ \n" +
+		" │I│ \n" +
+		" └─┘ \n" +
+		"     \n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render(): got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLoopErrors(t *testing.T) {
+	type test struct {
+		doc string
+		err error
+	}
+
+	for tn, tc := range map[string]test{
+		"dead end": {"S-7\n..|", ErrNoLoop},
+		"isolated": {"...\n.S.\n...", ErrNoLoop},
+		// Two loops meet at the start, which could be J or F.
+		"figure of eight": {`F-7..
+|.|..
+L-S-7
+..|.|
+..L-J`, ErrInvalidMaze},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				m, err := FromDocument(bytes.NewBufferString(tc.doc))
+				if err != nil {
+					t.Fatalf("FromDocument(): unexpected error: %v", err)
+				}
+				if _, err := m.Loop(); !errors.Is(err, tc.err) {
+					t.Errorf("Loop(): got error: %v want: %v", err, tc.err)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestFromDocumentErrors(t *testing.T) {
+	for tn, doc := range map[string]string{
+		"empty":        "",
+		"no start":     "F7\nLJ",
+		"two starts":   "S7\nLS",
+		"unknown tile": "S7\nLX",
+		// A blank line would otherwise shift the bottom of the loop down a row.
+		"blank line within": "F-7\n|.|\n\nS-J",
+	} {
+		func(t *testing.T, tn, doc string) {
+			t.Run(tn, func(t *testing.T) {
+				if _, err := FromDocument(bytes.NewBufferString(doc)); !errors.Is(err, ErrInvalidMaze) {
+					t.Errorf("FromDocument(): got error: %v want: %v", err, ErrInvalidMaze)
+				}
+			})
+		}(t, tn, doc)
+	}
+}
@@ -0,0 +1,47 @@
+package pipes
+
+import (
+	"io"
+	"strings"
+
+	"github.com/cfunkhouser/aoc2023/grid"
+)
+
+// boxDrawing characters for each shape of pipe.
+var boxDrawing = map[rune]rune{
+	'|': '│',
+	'-': '─',
+	'L': '└',
+	'J': '┘',
+	'7': '┐',
+	'F': '┌',
+}
+
+// Render the maze to w, with the loop drawn in box-drawing characters and the
+// start tile marked S. Tiles enclosed by the loop are drawn as I, and all
+// others, including pipes not on the loop, as a space.
+func (l *Loop) Render(w io.Writer) error {
+	inside := make(map[grid.Point]bool)
+	for _, p := range l.Inside() {
+		inside[p] = true
+	}
+	var buf strings.Builder
+	for y := 0; y < l.maze.Height; y++ {
+		for x := 0; x < l.maze.Width; x++ {
+			p := grid.Point{X: x, Y: y}
+			switch {
+			case p == l.maze.Start:
+				buf.WriteRune('S')
+			case l.on[p]:
+				buf.WriteRune(boxDrawing[l.Shape(p)])
+			case inside[p]:
+				buf.WriteRune('I')
+			default:
+				buf.WriteRune(' ')
+			}
+		}
+		buf.WriteRune('\n')
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
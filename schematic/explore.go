@@ -0,0 +1,90 @@
+package schematic
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/cfunkhouser/aoc2023/gondola"
+	"github.com/spf13/cobra"
+)
+
+// explore the grid interactively on the terminal tty, until the user quits.
+func explore(g *gondola.Grid, tty *os.File) error {
+	restore, err := makeRaw(tty)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	io.WriteString(tty, enterAltScreen+hideCursor)
+	defer io.WriteString(tty, showCursor+exitAltScreen)
+
+	e := newExplorer(g)
+	r := bufio.NewReader(tty)
+	for {
+		cols, rows, err := termSize(tty)
+		if err != nil || cols == 0 || rows == 0 {
+			cols, rows = 80, 24
+		}
+		var buf bytes.Buffer
+		e.render(&buf, cols, rows)
+		if _, err := tty.Write(buf.Bytes()); err != nil {
+			return err
+		}
+		k, err := readKey(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if e.handle(k) {
+			return nil
+		}
+	}
+}
+
+var (
+	exploreFilePath string
+
+	exploreCmd = &cobra.Command{
+		Use:   "explore",
+		Short: "Interactively explore a gondola schematic.",
+		Long: `Interactively explore a gondola schematic in the terminal.
+
+Move the cursor with the arrow keys or h, j, k and l. The cell under the cursor
+is described below the schematic, along with its neighbourhood. Press / to
+search for a number or symbol, n to find the next match, and q to quit.
+
+Part numbers are shown in green, other numbers in red, and gears in yellow.
+
+If no value is provided for -f / --file the document is read from STDIN. Either
+way, keys are read from the controlling terminal.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if exploreFilePath != "" {
+				var err error
+				if f, err = os.Open(exploreFilePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			g := gondola.GridFromDocument(f)
+			tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+			if err != nil {
+				return err
+			}
+			defer tty.Close()
+			return explore(g, tty)
+		},
+	}
+)
+
+func init() {
+	exploreCmd.Flags().StringVarP(&exploreFilePath, "file", "f", "",
+		"Path to the gondola schematic. Optional.")
+	schematicCmd.AddCommand(exploreCmd)
+}
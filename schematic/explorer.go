@@ -0,0 +1,335 @@
+package schematic
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cfunkhouser/aoc2023/gondola"
+)
+
+// ANSI escape sequences used by the explorer.
+const (
+	enterAltScreen = "\x1b[?1049h"
+	exitAltScreen  = "\x1b[?1049l"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+	home           = "\x1b[H"
+	clearToEOL     = "\x1b[K"
+	clearToEOS     = "\x1b[J"
+
+	styleReset   = "\x1b[0m"
+	styleCursor  = "\x1b[7m"
+	stylePart    = "\x1b[1;32m"
+	styleNotPart = "\x1b[31m"
+	styleGear    = "\x1b[1;33m"
+	styleSymbol  = "\x1b[36m"
+)
+
+// panelHeight is the number of terminal rows below the grid used to describe
+// the cell under the cursor.
+const panelHeight = 6
+
+type key int
+
+const (
+	keyNone key = iota
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyEnter
+	keyBackspace
+	keyEscape
+	keyInterrupt
+	keyRune
+)
+
+// keypress read from the terminal. r is only meaningful for keyRune.
+type keypress struct {
+	key key
+	r   rune
+}
+
+// readKey decodes a single keypress from a terminal in raw mode. Escape
+// sequences for the arrow keys are recognized; any other sequence is ignored.
+func readKey(r *bufio.Reader) (keypress, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return keypress{}, err
+	}
+	switch c {
+	case '\x1b':
+		// A lone escape arrives without a sequence following it.
+		if r.Buffered() == 0 {
+			return keypress{key: keyEscape}, nil
+		}
+		if b, _ := r.ReadByte(); b != '[' && b != 'O' {
+			return keypress{}, nil
+		}
+		switch b, _ := r.ReadByte(); b {
+		case 'A':
+			return keypress{key: keyUp}, nil
+		case 'B':
+			return keypress{key: keyDown}, nil
+		case 'C':
+			return keypress{key: keyRight}, nil
+		case 'D':
+			return keypress{key: keyLeft}, nil
+		}
+		return keypress{}, nil
+	case '\r', '\n':
+		return keypress{key: keyEnter}, nil
+	case '\x7f', '\b':
+		return keypress{key: keyBackspace}, nil
+	case '\x03', '\x04':
+		return keypress{key: keyInterrupt}, nil
+	}
+	return keypress{key: keyRune, r: c}, nil
+}
+
+// explorer is the state of an interactive exploration of a schematic.
+type explorer struct {
+	g *gondola.Grid
+
+	// x, y is the cursor position within the grid.
+	x, y int
+	// ox, oy is the grid position shown in the top left corner of the view.
+	ox, oy int
+
+	// searching is true while a query is being entered.
+	searching bool
+	query     string
+	lastQuery string
+
+	status string
+}
+
+func newExplorer(g *gondola.Grid) *explorer {
+	return &explorer{
+		g:      g,
+		status: "arrows/hjkl: move  /: search  n: next match  q: quit",
+	}
+}
+
+// handle a keypress, returning true when the explorer should exit.
+func (e *explorer) handle(k keypress) (quit bool) {
+	if k.key == keyInterrupt {
+		return true
+	}
+	if e.searching {
+		switch k.key {
+		case keyEnter:
+			e.searching = false
+			e.lastQuery = e.query
+			e.search(e.query)
+		case keyEscape:
+			e.searching = false
+			e.status = ""
+		case keyBackspace:
+			_, sz := utf8.DecodeLastRuneInString(e.query)
+			e.query = e.query[:len(e.query)-sz]
+		case keyRune:
+			e.query += string(k.r)
+		}
+		return false
+	}
+	switch k.key {
+	case keyUp:
+		e.move(0, -1)
+	case keyDown:
+		e.move(0, 1)
+	case keyLeft:
+		e.move(-1, 0)
+	case keyRight:
+		e.move(1, 0)
+	case keyRune:
+		switch k.r {
+		case 'q':
+			return true
+		case 'k':
+			e.move(0, -1)
+		case 'j':
+			e.move(0, 1)
+		case 'h':
+			e.move(-1, 0)
+		case 'l':
+			e.move(1, 0)
+		case '/':
+			e.searching = true
+			e.query = ""
+		case 'n':
+			e.search(e.lastQuery)
+		}
+	}
+	return false
+}
+
+// move the cursor by dx, dy. Horizontal movement treats a multi-digit number
+// as a single cell, so the cursor always rests on its leftmost column.
+func (e *explorer) move(dx, dy int) {
+	w, h := e.g.Size()
+	if dx > 0 {
+		if c := e.g.At(e.x, e.y); c != nil {
+			dx = e.g.Width(c)
+		}
+	}
+	x, y := e.x+dx, e.y+dy
+	if x < 0 || y < 0 || x >= w || y >= h {
+		return
+	}
+	if c := e.g.At(x, y); c != nil {
+		x, _, _ = e.g.Position(c)
+	}
+	e.x, e.y = x, y
+}
+
+// matcher for a search query: a number matches cells with that value, and any
+// other single character matches symbols.
+func matcher(q string) (func(c *gondola.Cell) bool, error) {
+	if n, err := strconv.Atoi(q); err == nil {
+		return func(c *gondola.Cell) bool {
+			v, ok := c.Number()
+			return ok && v == n
+		}, nil
+	}
+	if r := []rune(q); len(r) == 1 {
+		return func(c *gondola.Cell) bool {
+			v, ok := c.Rune()
+			return ok && v == r[0]
+		}, nil
+	}
+	return nil, fmt.Errorf("search for a number or a single symbol, not %q", q)
+}
+
+// search moves the cursor to the next cell after it, in reading order, which
+// matches q, wrapping around to the start of the grid if necessary.
+func (e *explorer) search(q string) {
+	if q == "" {
+		return
+	}
+	match, err := matcher(q)
+	if err != nil {
+		e.status = err.Error()
+		return
+	}
+	var first *gondola.Cell
+	for _, c := range e.g.Cells() {
+		if !match(c) {
+			continue
+		}
+		if first == nil {
+			first = c
+		}
+		if x, y, _ := e.g.Position(c); y > e.y || (y == e.y && x > e.x) {
+			e.x, e.y = x, y
+			e.status = fmt.Sprintf("found %s at (%d,%d)", c, x, y)
+			return
+		}
+	}
+	if first == nil {
+		e.status = fmt.Sprintf("%s not found", q)
+		return
+	}
+	e.x, e.y, _ = e.g.Position(first)
+	e.status = fmt.Sprintf("found %s at (%d,%d), wrapped", first, e.x, e.y)
+}
+
+// scroll the view so that the cursor is visible within cols by rows.
+func (e *explorer) scroll(cols, rows int) {
+	if e.x < e.ox {
+		e.ox = e.x
+	} else if e.x >= e.ox+cols {
+		e.ox = e.x - cols + 1
+	}
+	if e.y < e.oy {
+		e.oy = e.y
+	} else if e.y >= e.oy+rows {
+		e.oy = e.y - rows + 1
+	}
+}
+
+// style for the cell, excluding the cursor.
+func (e *explorer) style(c *gondola.Cell) string {
+	if _, ok := c.Number(); ok {
+		if e.g.IsPartNumber(c) {
+			return stylePart
+		}
+		return styleNotPart
+	}
+	if c.GearRatio() != 0 {
+		return styleGear
+	}
+	return styleSymbol
+}
+
+// glyph at column x of row y.
+func (e *explorer) glyph(x, y int) string {
+	c := e.g.At(x, y)
+	if c == nil {
+		return "."
+	}
+	cx, _, _ := e.g.Position(c)
+	return string([]rune(c.String())[x-cx])
+}
+
+// describe the cell under the cursor, one line at a time.
+func (e *explorer) describe() (lines []string) {
+	c := e.g.At(e.x, e.y)
+	if c == nil {
+		return []string{fmt.Sprintf("(%d,%d) empty", e.x, e.y)}
+	}
+	info := fmt.Sprintf("(%d,%d) %s", e.x, e.y, c)
+	if _, ok := c.Number(); ok {
+		info += fmt.Sprintf("  part number: %v", e.g.IsPartNumber(c))
+	} else if ratio := c.GearRatio(); ratio != 0 {
+		info += fmt.Sprintf("  gear ratio: %d", ratio)
+	} else {
+		info += "  not a gear"
+	}
+	lines = append(lines, info)
+	return append(lines, strings.Split(strings.TrimSuffix(c.DebugString(), "\n"), "\n")...)
+}
+
+// render the explorer to w, filling a terminal of cols by rows.
+func (e *explorer) render(w io.Writer, cols, rows int) {
+	gridRows := max(rows-panelHeight, 1)
+	e.scroll(cols, gridRows)
+	cursor := e.g.At(e.x, e.y)
+
+	fmt.Fprint(w, home)
+	for y := e.oy; y < e.oy+gridRows; y++ {
+		var line strings.Builder
+		for x := e.ox; x < e.ox+cols; x++ {
+			c := e.g.At(x, y)
+			switch {
+			case (c != nil && c == cursor) || (x == e.x && y == e.y):
+				line.WriteString(styleCursor + e.glyph(x, y) + styleReset)
+			case c != nil:
+				line.WriteString(e.style(c) + e.glyph(x, y) + styleReset)
+			default:
+				if gw, gh := e.g.Size(); x < gw && y < gh {
+					line.WriteString(".")
+				}
+			}
+		}
+		fmt.Fprint(w, line.String()+clearToEOL+"\n")
+	}
+
+	panel := e.describe()
+	for len(panel) < panelHeight-1 {
+		panel = append(panel, "")
+	}
+	for _, l := range panel[:panelHeight-1] {
+		fmt.Fprint(w, l+clearToEOL+"\n")
+	}
+	if e.searching {
+		fmt.Fprint(w, "/"+e.query+clearToEOL)
+	} else {
+		fmt.Fprint(w, e.status+clearToEOL)
+	}
+	fmt.Fprint(w, clearToEOS)
+}
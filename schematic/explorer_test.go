@@ -0,0 +1,196 @@
+package schematic
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cfunkhouser/aoc2023/gondola"
+	"github.com/google/go-cmp/cmp"
+)
+
+const exampleSchematic = `467..114..
+...*......
+..35..633.
+......#...
+617*......
+.....+.58.
+..592.....
+......755.
+...$.*....
+.664.598..`
+
+func TestReadKey(t *testing.T) {
+	type test struct {
+		in   string
+		want []keypress
+	}
+
+	for tn, tc := range map[string]test{
+		"arrows": {
+			in:   "\x1b[A\x1b[B\x1b[C\x1b[D",
+			want: []keypress{{key: keyUp}, {key: keyDown}, {key: keyRight}, {key: keyLeft}},
+		},
+		"runes": {
+			in:   "q/7",
+			want: []keypress{{keyRune, 'q'}, {keyRune, '/'}, {keyRune, '7'}},
+		},
+		"control keys": {
+			in:   "\r\x7f\x03",
+			want: []keypress{{key: keyEnter}, {key: keyBackspace}, {key: keyInterrupt}},
+		},
+		"lone escape": {
+			in:   "\x1b",
+			want: []keypress{{key: keyEscape}},
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				r := bufio.NewReader(strings.NewReader(tc.in))
+				var got []keypress
+				for range tc.want {
+					k, err := readKey(r)
+					if err != nil {
+						t.Fatalf("readKey(): unexpected error: %v", err)
+					}
+					got = append(got, k)
+				}
+				if diff := cmp.Diff(got, tc.want, cmp.AllowUnexported(keypress{})); diff != "" {
+					t.Errorf("readKey(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func explorerForTesting(tb testing.TB) *explorer {
+	tb.Helper()
+	return newExplorer(gondola.GridFromDocument(bytes.NewBufferString(exampleSchematic)))
+}
+
+func keys(s string) (ret []keypress) {
+	for _, r := range s {
+		if r == '\r' {
+			ret = append(ret, keypress{key: keyEnter})
+			continue
+		}
+		ret = append(ret, keypress{keyRune, r})
+	}
+	return
+}
+
+func TestExplorerHandle(t *testing.T) {
+	type test struct {
+		keys         []keypress
+		wantX, wantY int
+	}
+
+	for tn, tc := range map[string]test{
+		"no keys": {},
+		"right skips over numbers": {
+			keys:  keys("l"),
+			wantX: 3,
+		},
+		"left lands on start of number": {
+			keys:  keys("lh"),
+			wantX: 0,
+		},
+		"down onto middle of number": {
+			keys:  keys("ljj"),
+			wantX: 2, wantY: 2,
+		},
+		"cannot leave the grid": {
+			keys: keys("kh"),
+		},
+		"search for number": {
+			keys:  keys("/633\r"),
+			wantX: 6, wantY: 2,
+		},
+		"search for symbol and repeat": {
+			keys:  keys("/*\rn"),
+			wantX: 3, wantY: 4,
+		},
+		"search wraps": {
+			keys:  keys("/$\rn"),
+			wantX: 3, wantY: 8,
+		},
+		"backspace edits the query": {
+			keys:  append(keys("/6"), keypress{key: keyBackspace}, keypress{keyRune, '#'}, keypress{key: keyEnter}),
+			wantX: 6, wantY: 3,
+		},
+		"backspace removes a whole rune": {
+			keys:  append(keys("/€"), keypress{key: keyBackspace}, keypress{keyRune, '#'}, keypress{key: keyEnter}),
+			wantX: 6, wantY: 3,
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				e := explorerForTesting(t)
+				for _, k := range tc.keys {
+					if e.handle(k) {
+						t.Fatalf("handle(%v): unexpected quit", k)
+					}
+				}
+				if e.x != tc.wantX || e.y != tc.wantY {
+					t.Errorf("handle(): cursor mismatch: got: (%d,%d) want: (%d,%d)", e.x, e.y, tc.wantX, tc.wantY)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestExplorerQuit(t *testing.T) {
+	e := explorerForTesting(t)
+	if !e.handle(keypress{keyRune, 'q'}) {
+		t.Error("handle('q'): did not quit")
+	}
+	e.handle(keypress{keyRune, '/'})
+	if e.handle(keypress{keyRune, 'q'}) {
+		t.Error("handle('q'): quit while searching")
+	}
+}
+
+func TestExplorerRender(t *testing.T) {
+	e := explorerForTesting(t)
+	e.x, e.y = 3, 4
+	var buf bytes.Buffer
+	e.render(&buf, 40, 20)
+	got := buf.String()
+	for _, want := range []string{
+		styleCursor + "*" + styleReset,
+		stylePart + "6" + styleReset,
+		styleNotPart + "1" + styleReset,
+		styleGear + "*" + styleReset,
+		"(3,4) *  not a gear",
+		"617 * .",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("render(): output does not contain %q", want)
+		}
+	}
+}
+
+func TestExplorerRenderScrolls(t *testing.T) {
+	e := explorerForTesting(t)
+	e.x, e.y = 9, 9
+	var buf bytes.Buffer
+	e.render(&buf, 4, panelHeight+2)
+	if e.ox != 6 || e.oy != 8 {
+		t.Errorf("render(): view origin mismatch: got: (%d,%d) want: (6,8)", e.ox, e.oy)
+	}
+}
+
+func TestExplorerGlyph(t *testing.T) {
+	e := newExplorer(gondola.GridFromDocument(bytes.NewBufferString("12§.\n.€34")))
+	var got []string
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			got = append(got, e.glyph(x, y))
+		}
+	}
+	want := []string{"1", "2", "§", ".", ".", "€", "3", "4"}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("glyph(): mismatch (-got,+want):\n%v", diff)
+	}
+}
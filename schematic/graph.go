@@ -0,0 +1,59 @@
+package schematic
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/cfunkhouser/aoc2023/gondola"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphFilePath string
+	graphFormat   string
+
+	graphCmd = &cobra.Command{
+		Use:   "graph",
+		Short: "Export the cell adjacency graph of a gondola schematic.",
+		Long: `Export the cell adjacency graph of a gondola schematic.
+
+Each number and symbol in the schematic is a node, carrying its value, kind,
+position and cluster of connected cells. Each edge links two adjacent cells and
+carries the direction of the second as seen from the first.
+
+The graph is written as Graphviz DOT (--format dot, the default) or as a JSON
+node and edge list (--format json).
+
+If no value is provided for -f / --file the document is read from STDIN.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if graphFormat != "dot" && graphFormat != "json" {
+				return fmt.Errorf("unsupported format %q", graphFormat)
+			}
+			f := os.Stdin
+			if graphFilePath != "" {
+				var err error
+				if f, err = os.Open(graphFilePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			g := gondola.GridFromDocument(f).Graph()
+			if graphFormat == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(g)
+			}
+			return g.WriteDOT(os.Stdout)
+		},
+	}
+)
+
+func init() {
+	graphCmd.Flags().StringVarP(&graphFilePath, "file", "f", "",
+		"Path to the gondola schematic. Optional.")
+	graphCmd.Flags().StringVar(&graphFormat, "format", "dot",
+		"Output format, either dot or json.")
+	schematicCmd.AddCommand(graphCmd)
+}
@@ -0,0 +1,44 @@
+package schematic
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cfunkhouser/aoc2023/gondola"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inspectFilePath string
+
+	inspectCmd = &cobra.Command{
+		Use:   "inspect",
+		Short: "Report diagnostics about a gondola schematic.",
+		Long: `Report diagnostics about a gondola schematic.
+
+The report includes numbers touching no symbol, numbers touching several
+symbols, '*' cells adjacent to one or three or more numbers, part numbers which
+appear at more than one position, and the frequency of each symbol.
+
+If no value is provided for -f / --file the document is read from STDIN.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if inspectFilePath != "" {
+				var err error
+				if f, err = os.Open(inspectFilePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			fmt.Print(gondola.GridFromDocument(f).Inspect())
+			return nil
+		},
+	}
+)
+
+func init() {
+	inspectCmd.Flags().StringVarP(&inspectFilePath, "file", "f", "",
+		"Path to the gondola schematic. Optional.")
+	schematicCmd.AddCommand(inspectCmd)
+}
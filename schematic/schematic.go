@@ -0,0 +1,18 @@
+// Package schematic exposes tools for working with gondola schematics outside
+// of solving for a star.
+package schematic
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var schematicCmd = &cobra.Command{
+	Use:   "schematic",
+	Short: "Tools for gondola schematics",
+	Long:  "Tools for gondola schematics",
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(schematicCmd)
+}
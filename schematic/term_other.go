@@ -0,0 +1,18 @@
+//go:build !linux
+
+package schematic
+
+import (
+	"errors"
+	"os"
+)
+
+var errUnsupported = errors.New("interactive exploration is only supported on Linux")
+
+func makeRaw(f *os.File) (restore func(), err error) {
+	return nil, errUnsupported
+}
+
+func termSize(f *os.File) (cols, rows int, err error) {
+	return 0, 0, errUnsupported
+}
@@ -0,0 +1,280 @@
+package scratchcards
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Scoring determines the point value of a card from its number of matches,
+// using exact integer arithmetic.
+type Scoring func(matches int) (*big.Int, error)
+
+var (
+	// Doubling scores one point for the first match, doubled for each match
+	// after that. This is the scoring used by the puzzle.
+	Doubling Scoring = func(matches int) (*big.Int, error) {
+		if matches < 1 {
+			return new(big.Int), nil
+		}
+		return new(big.Int).Lsh(big.NewInt(1), uint(matches-1)), nil
+	}
+
+	// Linear scores one point per match.
+	Linear Scoring = func(matches int) (*big.Int, error) {
+		return big.NewInt(int64(matches)), nil
+	}
+
+	// Fibonacci scores the matches-th Fibonacci number: 0 for no matches, then
+	// 1, 1, 2, 3, 5 and so on.
+	Fibonacci Scoring = func(matches int) (*big.Int, error) {
+		a, b := new(big.Int), big.NewInt(1)
+		for i := 0; i < matches; i++ {
+			a.Add(a, b)
+			a, b = b, a
+		}
+		return a, nil
+	}
+)
+
+// ParseScoring by name, either "doubling", "linear" or "fibonacci". Anything
+// else is parsed as an Expression.
+func ParseScoring(s string) (Scoring, error) {
+	switch s {
+	case "doubling":
+		return Doubling, nil
+	case "linear":
+		return Linear, nil
+	case "fibonacci":
+		return Fibonacci, nil
+	}
+	return Expression(s)
+}
+
+// Score of each card in the stack, summed.
+func (cards Cards) Score(scoring Scoring) (*big.Int, error) {
+	total := new(big.Int)
+	for _, c := range cards {
+		points, err := scoring(len(c.Matches))
+		if err != nil {
+			return nil, fmt.Errorf("card %d: %w", c.ID, err)
+		}
+		total.Add(total, points)
+	}
+	return total, nil
+}
+
+// maxPowerBits bounds the size in bits of the result of ^ in an Expression, so
+// that a typo cannot exhaust memory. The size is estimated before the power is
+// computed, as the bit length of the base multiplied by the exponent.
+const maxPowerBits = 1 << 18
+
+// expr is a compiled Expression, evaluated for the number of matches m.
+type expr func(m *big.Int) (*big.Int, error)
+
+// ErrInvalidExpression is returned when a scoring expression cannot be parsed.
+var ErrInvalidExpression = errors.New("invalid scoring expression")
+
+// Expression compiles a scoring expression over the number of matches, m. It
+// supports integer literals, the operators + - * / % and ^ (exponentiation),
+// unary minus and parentheses, with the usual precedence. Division truncates
+// toward zero. For example, the Doubling scoring could be written as:
+//
+//	(2^m)/2
+func Expression(s string) (Scoring, error) {
+	p := &exprParser{s: s}
+	e, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos])
+	}
+	return func(matches int) (*big.Int, error) {
+		return e(big.NewInt(int64(matches)))
+	}, nil
+}
+
+type exprParser struct {
+	s   string
+	pos int
+}
+
+func (p *exprParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w at offset %d: %s", ErrInvalidExpression, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// accept the next operator if it is one of ops, returning it.
+func (p *exprParser) accept(ops string) (byte, bool) {
+	p.skipSpace()
+	if p.pos < len(p.s) && strings.IndexByte(ops, p.s[p.pos]) >= 0 {
+		p.pos++
+		return p.s[p.pos-1], true
+	}
+	return 0, false
+}
+
+// binary combines two expressions with an arithmetic operator.
+func binary(op byte, l, r expr) expr {
+	return func(m *big.Int) (*big.Int, error) {
+		lv, err := l(m)
+		if err != nil {
+			return nil, err
+		}
+		rv, err := r(m)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case '+':
+			return lv.Add(lv, rv), nil
+		case '-':
+			return lv.Sub(lv, rv), nil
+		case '*':
+			return lv.Mul(lv, rv), nil
+		case '/', '%':
+			if rv.Sign() == 0 {
+				return nil, errors.New("division by zero")
+			}
+			if op == '/' {
+				return lv.Quo(lv, rv), nil
+			}
+			return lv.Rem(lv, rv), nil
+		case '^':
+			if rv.Sign() < 0 {
+				return nil, errors.New("negative exponent")
+			}
+			// Powers of 0, 1 and -1 stay small however large the exponent.
+			if lv.CmpAbs(big.NewInt(1)) > 0 {
+				bits := new(big.Int).Mul(big.NewInt(int64(lv.BitLen())), rv)
+				if bits.Cmp(big.NewInt(maxPowerBits)) > 0 {
+					return nil, fmt.Errorf("power of a %d-bit base to %s exceeds %d bits", lv.BitLen(), rv, maxPowerBits)
+				}
+			}
+			return lv.Exp(lv, rv, nil), nil
+		}
+		panic("unknown operator")
+	}
+}
+
+// parseSum parses terms separated by + or -.
+func (p *exprParser) parseSum() (expr, error) {
+	e, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("+-")
+		if !ok {
+			return e, nil
+		}
+		r, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		e = binary(op, e, r)
+	}
+}
+
+// parseProduct parses factors separated by *, / or %.
+func (p *exprParser) parseProduct() (expr, error) {
+	e, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("*/%")
+		if !ok {
+			return e, nil
+		}
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		e = binary(op, e, r)
+	}
+}
+
+// parseUnary parses an optionally negated power.
+func (p *exprParser) parseUnary() (expr, error) {
+	if _, ok := p.accept("-"); ok {
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(m *big.Int) (*big.Int, error) {
+			v, err := e(m)
+			if err != nil {
+				return nil, err
+			}
+			return v.Neg(v), nil
+		}, nil
+	}
+	return p.parsePower()
+}
+
+// parsePower parses exponentiation, which is right associative.
+func (p *exprParser) parsePower() (expr, error) {
+	e, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.accept("^"); !ok {
+		return e, nil
+	}
+	r, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return binary('^', e, r), nil
+}
+
+// isDigit is true for the ASCII digits. Expressions are parsed byte by byte,
+// so other digits, and bytes of multi-byte runes, are not numbers.
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// parsePrimary parses a literal, the variable m, or a parenthesized
+// expression.
+func (p *exprParser) parsePrimary() (expr, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) {
+		return nil, p.errorf("unexpected end of expression")
+	}
+	switch c := p.s[p.pos]; {
+	case c == '(':
+		p.pos++
+		e, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, p.errorf("missing )")
+		}
+		return e, nil
+	case c == 'm':
+		p.pos++
+		return func(m *big.Int) (*big.Int, error) {
+			return new(big.Int).Set(m), nil
+		}, nil
+	case isDigit(c):
+		start := p.pos
+		for p.pos < len(p.s) && isDigit(p.s[p.pos]) {
+			p.pos++
+		}
+		n, _ := new(big.Int).SetString(p.s[start:p.pos], 10)
+		return func(*big.Int) (*big.Int, error) {
+			return new(big.Int).Set(n), nil
+		}, nil
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
@@ -0,0 +1,124 @@
+package scratchcards
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestScorings(t *testing.T) {
+	type test struct {
+		scoring string
+		want    []string // indexed by number of matches
+	}
+
+	for tn, tc := range map[string]test{
+		"doubling":  {"doubling", []string{"0", "1", "2", "4", "8", "16"}},
+		"linear":    {"linear", []string{"0", "1", "2", "3", "4", "5"}},
+		"fibonacci": {"fibonacci", []string{"0", "1", "1", "2", "3", "5"}},
+		"constant":  {"7", []string{"7", "7", "7", "7", "7", "7"}},
+		"squares":   {"m * m", []string{"0", "1", "4", "9", "16", "25"}},
+		"doubling as expression": {
+			"(2^m)/2", []string{"0", "1", "2", "4", "8", "16"},
+		},
+		"precedence":     {"1 + 2 * m ^ 2", []string{"1", "3", "9", "19", "33", "51"}},
+		"right assoc":    {"2^m^2", []string{"1", "2", "16", "512", "65536", "33554432"}},
+		"unary minus":    {"-m^2 + 30", []string{"30", "29", "26", "21", "14", "5"}},
+		"modulo":         {"m % 2", []string{"0", "1", "0", "1", "0", "1"}},
+		"truncating":     {"(m - 3) / 2", []string{"-1", "-1", "0", "0", "0", "1"}},
+		"spaces ignored": {" ( m+1 ) ", []string{"1", "2", "3", "4", "5", "6"}},
+		"huge power of one": {
+			"(m % 2 * 2 - 1) ^ (10 ^ 100)", []string{"1", "1", "1", "1", "1", "1"},
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				s, err := ParseScoring(tc.scoring)
+				if err != nil {
+					t.Fatalf("ParseScoring(%q): unexpected error: %v", tc.scoring, err)
+				}
+				for m, want := range tc.want {
+					got, err := s(m)
+					if err != nil {
+						t.Fatalf("Scoring(%d): unexpected error: %v", m, err)
+					}
+					if got.String() != want {
+						t.Errorf("Scoring(%d): mismatch: got: %s want: %s", m, got, want)
+					}
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestDoublingBeyondInt(t *testing.T) {
+	got, err := Doubling(100)
+	if err != nil {
+		t.Fatalf("Doubling(100): unexpected error: %v", err)
+	}
+	if want := "633825300114114700748351602688"; got.String() != want {
+		t.Errorf("Doubling(100): mismatch: got: %s want: %s", got, want)
+	}
+}
+
+func TestExpressionErrors(t *testing.T) {
+	type test struct {
+		expr         string
+		wantParseErr bool
+	}
+
+	for tn, tc := range map[string]test{
+		"empty":              {"", true},
+		"unknown variable":   {"n + 1", true},
+		"unbalanced":         {"(m + 1", true},
+		"trailing operator":  {"m +", true},
+		"trailing input":     {"m 2", true},
+		"non-ASCII digits":   {"m + ²", true},
+		"Arabic-Indic digit": {"m + ٣", true},
+		"division by zero":   {"m / 0", false},
+		"modulo by zero":     {"m % (m - m)", false},
+		"negative exponent":  {"2 ^ -m", false},
+		"excessive exponent": {"2 ^ (m * 1000000)", false},
+		"excessive base":     {"(m + 100) ^ 65536", false},
+		"nested power":       {"(2 ^ 65536) ^ 65536", false},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				s, err := Expression(tc.expr)
+				if tc.wantParseErr {
+					if !errors.Is(err, ErrInvalidExpression) {
+						t.Errorf("Expression(%q): got error %v want ErrInvalidExpression", tc.expr, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Expression(%q): unexpected error: %v", tc.expr, err)
+				}
+				if _, err := s(3); err == nil {
+					t.Errorf("Scoring(3): expected error")
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestCardsScore(t *testing.T) {
+	cards := cardsForTesting(t, exampleCards)
+	for scoring, want := range map[string]string{
+		"doubling":  "13",
+		"linear":    "9",
+		"fibonacci": "6",
+	} {
+		s, _ := ParseScoring(scoring)
+		got, err := cards.Score(s)
+		if err != nil {
+			t.Fatalf("Score(%s): unexpected error: %v", scoring, err)
+		}
+		if got.String() != want {
+			t.Errorf("Score(%s): mismatch: got: %s want: %s", scoring, got, want)
+		}
+	}
+	s, _ := Expression("1 / (m - 1)")
+	if _, err := cards.Score(s); err == nil {
+		t.Error("Score(1 / (m - 1)): expected error")
+	}
+}
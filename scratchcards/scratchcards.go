@@ -0,0 +1,237 @@
+// Package scratchcards models the scratch off cards used by stars seven and
+// eight.
+// See: https://adventofcode.com/2023/day/4
+package scratchcards
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cfunkhouser/aoc2023/util"
+)
+
+// Card is a single scratch off card.
+type Card struct {
+	ID      int
+	Winning []int
+	Have    []int
+	// Matches are the winning numbers which also appear in Have, sorted.
+	Matches []int
+}
+
+// String produces a compact representation of the card's ID and number of
+// matches.
+func (c *Card) String() string {
+	if len(c.Matches) < 1 {
+		return fmt.Sprintf("[%-5d]", c.ID)
+	}
+	return fmt.Sprintf("[%-2d+%2d]", c.ID, len(c.Matches))
+}
+
+// ErrInvalidCard is returned when a card cannot be parsed.
+var ErrInvalidCard = errors.New("invalid Card")
+
+func parseNumbers(s string) ([]int, error) {
+	ret, err := util.FieldInts(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCard, err)
+	}
+	return ret, nil
+}
+
+// Parse a card from a line of the form:
+//
+//	Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53
+func Parse(s string) (*Card, error) {
+	widx := strings.Index(s, ":")
+	hidx := strings.Index(s, "|")
+	if widx == -1 || hidx == -1 || hidx < widx {
+		return nil, ErrInvalidCard
+	}
+
+	label, ok := strings.CutPrefix(s[:widx], "Card")
+	if !ok {
+		return nil, fmt.Errorf("%w: missing Card label", ErrInvalidCard)
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(label))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCard, err)
+	}
+
+	winning, err := parseNumbers(s[widx+1 : hidx])
+	if err != nil {
+		return nil, err
+	}
+	have, err := parseNumbers(s[hidx+1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Card{
+		ID:      id,
+		Winning: winning,
+		Have:    have,
+		Matches: util.Sorted(util.NewSet(winning).Intersection(util.NewSet(have))),
+	}, nil
+}
+
+// Cards is a collection of cards.
+type Cards []*Card
+
+func (cards Cards) String() string {
+	var buf bytes.Buffer
+	for _, c := range cards {
+		if _, err := buf.WriteString(c.String()); err != nil {
+			panic(err)
+		}
+	}
+	return buf.String()
+}
+
+var (
+	// ErrDuplicateCard is returned when two cards share an ID.
+	ErrDuplicateCard = errors.New("duplicate card")
+	// ErrCardOutOfRange is returned when a card wins copies of cards past the
+	// last one.
+	ErrCardOutOfRange = errors.New("card wins copies past the last card")
+)
+
+// cascade describes which cards win copies of which others. Cards are resolved
+// by ID, so the stack may be in any order and its IDs need not be contiguous.
+type cascade struct {
+	// order of the cards by ascending ID, as indices into the stack.
+	order []int
+	// end of the cards won by each card: the card at order[i] wins a copy of
+	// each card from order[i+1] up to, but not including, order[end[i]].
+	end []int
+}
+
+// resolve the cascade of the cards. Each card wins a copy of each card with
+// the next len(Matches) IDs after its own; IDs which are missing from the
+// stack win nothing.
+func (cards Cards) resolve() (*cascade, error) {
+	ncards := len(cards)
+	c := &cascade{
+		order: make([]int, ncards),
+		end:   make([]int, ncards),
+	}
+	for i := range c.order {
+		c.order[i] = i
+	}
+	id := func(i int) int {
+		return cards[c.order[i]].ID
+	}
+	if !slices.IsSortedFunc(cards, func(l, r *Card) int { return cmp.Compare(l.ID, r.ID) }) {
+		slices.SortFunc(c.order, func(l, r int) int { return cmp.Compare(cards[l].ID, cards[r].ID) })
+	}
+	for i := range c.order {
+		if i > 0 && id(i) == id(i-1) {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateCard, id(i))
+		}
+	}
+	for i, ci := range c.order {
+		m := len(cards[ci].Matches)
+		if m > 0 && id(i)+m > id(ncards-1) {
+			return nil, fmt.Errorf("%w: card %d wins copies up to card %d, but the last is %d",
+				ErrCardOutOfRange, id(i), id(i)+m, id(ncards-1))
+		}
+		// IDs are distinct and ascending, so the cards won lie within the
+		// next m positions.
+		last := id(i) + m
+		hi := min(i+1+m, ncards)
+		c.end[i] = i + 1 + sort.Search(hi-i-1, func(j int) bool {
+			return id(i+1+j) > last
+		})
+	}
+	return c, nil
+}
+
+// Count the total number of cards once every won copy has been included. Each
+// instance of a card wins one copy of each of the cards with the next
+// len(Matches) IDs, so the instances of each card are carried forward in a
+// running difference rather than expanded. This takes time linear in the
+// number of cards when they are in ID order, regardless of how many copies
+// are won. The total overflows silently; use CountBig when it may not fit in
+// an int.
+func (cards Cards) Count() (total int, err error) {
+	c, err := cards.resolve()
+	if err != nil {
+		return 0, err
+	}
+	diff := make([]int, len(cards)+1)
+	var won int
+	for i := range c.order {
+		won += diff[i]
+		// Each card is counted once before copies are included.
+		instances := 1 + won
+		total += instances
+		if end := c.end[i]; end > i+1 {
+			diff[i+1] += instances
+			diff[end] -= instances
+		}
+	}
+	return
+}
+
+// CountBig is Count using arbitrary-precision arithmetic, for totals which
+// overflow an int.
+func (cards Cards) CountBig() (*big.Int, error) {
+	instances, err := cards.Instances()
+	if err != nil {
+		return nil, err
+	}
+	total := new(big.Int)
+	for _, n := range instances {
+		total.Add(total, n)
+	}
+	return total, nil
+}
+
+// Instances of each card once every won copy has been included, using
+// arbitrary-precision arithmetic, indexed in the same order as the cards. See
+// Count for details.
+func (cards Cards) Instances() ([]*big.Int, error) {
+	c, err := cards.resolve()
+	if err != nil {
+		return nil, err
+	}
+	diff := make([]big.Int, len(cards)+1)
+	ret := make([]*big.Int, len(cards))
+	var won big.Int
+	one := big.NewInt(1)
+	for i, ci := range c.order {
+		won.Add(&won, &diff[i])
+		instances := new(big.Int).Add(&won, one)
+		ret[ci] = instances
+		if end := c.end[i]; end > i+1 {
+			diff[i+1].Add(&diff[i+1], instances)
+			diff[end].Sub(&diff[end], instances)
+		}
+	}
+	return ret, nil
+}
+
+// FromDocument parses a stack of cards, one per line.
+func FromDocument(doc io.Reader) (cards Cards, err error) {
+	s := bufio.NewScanner(doc)
+	for line := 1; s.Scan(); line++ {
+		card, err := Parse(s.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		cards = append(cards, card)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return
+}
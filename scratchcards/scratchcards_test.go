@@ -0,0 +1,257 @@
+package scratchcards
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const exampleCards = `Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53
+Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19
+Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1
+Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83
+Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36
+Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11`
+
+func TestParse(t *testing.T) {
+	type test struct {
+		line    string
+		want    *Card
+		wantErr bool
+	}
+
+	for tn, tc := range map[string]test{
+		"zero": {wantErr: true},
+		"valid": {
+			line: "Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+			want: &Card{
+				ID:      1,
+				Winning: []int{41, 48, 83, 86, 17},
+				Have:    []int{83, 86, 6, 31, 17, 9, 48, 53},
+				Matches: []int{17, 48, 83, 86},
+			},
+		},
+		"padded ID": {
+			line: "Card  12: 1 2 | 3 4",
+			want: &Card{
+				ID:      12,
+				Winning: []int{1, 2},
+				Have:    []int{3, 4},
+			},
+		},
+		"missing label":      {line: "1: 41 48 | 83 86", wantErr: true},
+		"missing ID":         {line: "Card : 41 48 | 83 86", wantErr: true},
+		"missing separator":  {line: "Card 1: 41 48 83 86", wantErr: true},
+		"invalid number":     {line: "Card 1: 41 4x | 83 86", wantErr: true},
+		"separators swapped": {line: "Card 1| 41 48 : 83 86", wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := Parse(tc.line)
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("Parse(): error mismatch: got: %v wantErr: %v", err, tc.wantErr)
+				}
+				if err != nil && !errors.Is(err, ErrInvalidCard) {
+					t.Errorf("Parse(): error %v is not ErrInvalidCard", err)
+				}
+				if diff := cmp.Diff(got, tc.want); diff != "" {
+					t.Errorf("Parse(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestCardMatches(t *testing.T) {
+	type test struct {
+		line string
+		want []int
+	}
+
+	for tn, tc := range map[string]test{
+		"example card 1": {"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53", []int{17, 48, 83, 86}},
+		"example card 2": {"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19", []int{32, 61}},
+		"example card 3": {"Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1", []int{1, 21}},
+		"example card 4": {"Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83", []int{84}},
+		"example card 5": {"Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36", nil},
+		"example card 6": {"Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11", nil},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				card, err := Parse(tc.line)
+				if err != nil {
+					t.Fatalf("Parse(): unexpected error: %v", err)
+				}
+				if diff := cmp.Diff(card.Matches, tc.want); diff != "" {
+					t.Errorf("Matches: mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func cardsForTesting(tb testing.TB, doc string) Cards {
+	tb.Helper()
+	cards, err := FromDocument(bytes.NewBufferString(doc))
+	if err != nil {
+		tb.Fatalf("FromDocument(): unexpected error: %v", err)
+	}
+	return cards
+}
+
+func TestFromDocument(t *testing.T) {
+	type test struct {
+		doc     string
+		want    string
+		wantErr bool
+	}
+
+	for tn, tc := range map[string]test{
+		"zero":                 {},
+		"example from problem": {doc: exampleCards, want: "[1 + 4][2 + 2][3 + 2][4 + 1][5    ][6    ]"},
+		"invalid line":         {doc: "Card 1: 1 | 1\nnonsense", wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := FromDocument(bytes.NewBufferString(tc.doc))
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("FromDocument(): error mismatch: got: %v wantErr: %v", err, tc.wantErr)
+				}
+				if diff := cmp.Diff(got.String(), tc.want); diff != "" {
+					t.Errorf("FromDocument(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+// cascadeForTesting produces n cards, each of which wins a copy of every card
+// after it, so that the total number of cards is 2^n - 1.
+func cascadeForTesting(n int) (cards Cards) {
+	for i := 1; i <= n; i++ {
+		cards = append(cards, &Card{ID: i, Matches: make([]int, n-i)})
+	}
+	return
+}
+
+// cardsWithMatches produces cards with the given IDs and numbers of matches,
+// in the order given.
+func cardsWithMatches(idsAndMatches ...int) (cards Cards) {
+	for i := 0; i+1 < len(idsAndMatches); i += 2 {
+		cards = append(cards, &Card{ID: idsAndMatches[i], Matches: make([]int, idsAndMatches[i+1])})
+	}
+	return
+}
+
+func TestCardsCount(t *testing.T) {
+	type test struct {
+		cards   Cards
+		want    int
+		wantErr error
+	}
+
+	for tn, tc := range map[string]test{
+		"zero":                 {},
+		"example from problem": {cards: cardsForTesting(t, exampleCards), want: 30},
+		"cascade":              {cards: cascadeForTesting(62), want: 1<<62 - 1},
+		"example reordered": {
+			cards: cardsWithMatches(6, 0, 4, 1, 1, 4, 5, 0, 3, 2, 2, 2),
+			want:  30,
+		},
+		"gaps win nothing": {
+			// Card 10 wins copies of 11 and 12, which is missing.
+			cards: cardsWithMatches(10, 2, 11, 2, 13, 0),
+			want:  1 + 2 + 3,
+		},
+		"first ID not one": {
+			cards: cardsWithMatches(100, 1, 101, 1, 102, 0),
+			want:  1 + 2 + 3,
+		},
+		"duplicate ID": {
+			cards:   cardsWithMatches(1, 1, 2, 0, 1, 0),
+			wantErr: ErrDuplicateCard,
+		},
+		"past the last card": {
+			cards:   cardsWithMatches(1, 1, 2, 1),
+			wantErr: ErrCardOutOfRange,
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := tc.cards.Count()
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Count(): error mismatch: got: %v want: %v", err, tc.wantErr)
+				}
+				if got != tc.want {
+					t.Errorf("Count(): mismatch: got: %d want: %d", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestCardsCountBig(t *testing.T) {
+	type test struct {
+		cards   Cards
+		want    string
+		wantErr error
+	}
+
+	for tn, tc := range map[string]test{
+		"zero":                 {want: "0"},
+		"example from problem": {cards: cardsForTesting(t, exampleCards), want: "30"},
+		"cascade beyond int": {
+			cards: cascadeForTesting(100),
+			want:  "1267650600228229401496703205375", // 2^100 - 1
+		},
+		"example reordered": {
+			cards: cardsWithMatches(6, 0, 4, 1, 1, 4, 5, 0, 3, 2, 2, 2),
+			want:  "30",
+		},
+		"duplicate ID": {
+			cards:   cardsWithMatches(1, 1, 2, 0, 2, 0),
+			wantErr: ErrDuplicateCard,
+		},
+		"past the last card": {
+			cards:   cardsWithMatches(2, 0, 1, 2),
+			wantErr: ErrCardOutOfRange,
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := tc.cards.CountBig()
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("CountBig(): error mismatch: got: %v want: %v", err, tc.wantErr)
+				}
+				if err == nil && got.String() != tc.want {
+					t.Errorf("CountBig(): mismatch: got: %s want: %s", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestCardsInstances(t *testing.T) {
+	cards := cardsWithMatches(6, 0, 4, 1, 1, 4, 5, 0, 3, 2, 2, 2)
+	got, err := cards.Instances()
+	if err != nil {
+		t.Fatalf("Instances(): unexpected error: %v", err)
+	}
+	want := []string{"1", "8", "1", "14", "4", "2"} // in the order of the cards
+	var gotStrings []string
+	for _, n := range got {
+		gotStrings = append(gotStrings, n.String())
+	}
+	if diff := cmp.Diff(gotStrings, want); diff != "" {
+		t.Errorf("Instances(): mismatch (-got,+want):\n%v", diff)
+	}
+}
+
+func BenchmarkCardsCount(b *testing.B) {
+	cards := cascadeForTesting(1000)
+	for i := 0; i < b.N; i++ {
+		_, _ = cards.Count()
+	}
+}
@@ -0,0 +1,52 @@
+package scratchcards
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+func joinNumbers(ns []int) string {
+	if len(ns) == 0 {
+		return "-"
+	}
+	s := make([]string, len(ns))
+	for i, n := range ns {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, " ")
+}
+
+// WriteTable writes a breakdown of the cards to w, one row per card, with
+// totals at the bottom. Each row shows the card's ID, its matching numbers, its
+// point value under the scoring, the number of copies of it won from earlier
+// cards, and the final number of instances of it.
+func (cards Cards) WriteTable(w io.Writer, scoring Scoring) error {
+	tw := tabwriter.NewWriter(w, 2, 1, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Card\tMatching numbers\tPoints\tCopies won\tInstances\t")
+
+	counts, err := cards.Instances()
+	if err != nil {
+		return err
+	}
+	points, copies, instances := new(big.Int), new(big.Int), new(big.Int)
+	one := big.NewInt(1)
+	for i, n := range counts {
+		c := cards[i]
+		p, err := scoring(len(c.Matches))
+		if err != nil {
+			return fmt.Errorf("card %d: %w", c.ID, err)
+		}
+		won := new(big.Int).Sub(n, one)
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t\n", c.ID, joinNumbers(c.Matches), p, won, n)
+		points.Add(points, p)
+		copies.Add(copies, won)
+		instances.Add(instances, n)
+	}
+
+	fmt.Fprintf(tw, "Total\t\t%s\t%s\t%s\t\n", points, copies, instances)
+	return tw.Flush()
+}
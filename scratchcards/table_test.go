@@ -0,0 +1,47 @@
+package scratchcards
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCardsWriteTable(t *testing.T) {
+	type test struct {
+		cards Cards
+		want  string
+	}
+
+	for tn, tc := range map[string]test{
+		"zero": {
+			want: `   Card  Matching numbers  Points  Copies won  Instances
+  Total                         0           0          0
+`,
+		},
+		"example from problem": {
+			cards: cardsForTesting(t, exampleCards),
+			want: `   Card  Matching numbers  Points  Copies won  Instances
+      1       17 48 83 86       8           0          1
+      2             32 61       2           1          2
+      3              1 21       2           3          4
+      4                84       1           7          8
+      5                 -       0          13         14
+      6                 -       0           0          1
+  Total                        13          24         30
+`,
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				var buf bytes.Buffer
+				if err := tc.cards.WriteTable(&buf, Doubling); err != nil {
+					t.Fatalf("WriteTable(): unexpected error: %v", err)
+				}
+				if diff := cmp.Diff(buf.String(), tc.want); diff != "" {
+					t.Errorf("WriteTable(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
@@ -0,0 +1,214 @@
+package scratchcards
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// Step in the cascade of copies won by a stack of cards, in which every
+// instance of a single card is scratched. Steps record only what changes, so
+// the instances of every card are rebuilt by applying them in turn to a stack
+// which starts with one instance of each.
+type Step struct {
+	// Card being scratched, and the number of instances of it.
+	Card      *Card
+	Instances *big.Int
+	// Awarded are the cards of which the step wins one copy per instance of
+	// Card, in ID order. Each gains Instances instances.
+	Awarded Cards
+
+	// index of Card, and of each of the Awarded cards, in the stack.
+	index   int
+	awarded []int
+}
+
+// initialCounts of instances of n cards, before any are scratched.
+func initialCounts(n int) []*big.Int {
+	counts := make([]*big.Int, n)
+	for i := range counts {
+		counts[i] = big.NewInt(1)
+	}
+	return counts
+}
+
+// apply the step to the instances of every card, indexed in the same order as
+// the cards.
+func (s *Step) apply(counts []*big.Int) {
+	for _, k := range s.awarded {
+		counts[k].Add(counts[k], s.Instances)
+	}
+}
+
+// Trace the cascade of copies won by the cards, one step per card in ID order.
+// Like Count, it resolves the cascade once and carries the number of instances
+// of each card forward, rather than visiting every awarded copy. Each step
+// holds only the cards it awards and the number of copies of each, so the trace
+// grows with the total number of matches rather than with the square of the
+// number of cards.
+func (cards Cards) Trace() ([]Step, error) {
+	c, err := cards.resolve()
+	if err != nil {
+		return nil, err
+	}
+	counts := initialCounts(len(cards))
+	var steps []Step
+	for i, ci := range c.order {
+		step := Step{
+			Card:      cards[ci],
+			Instances: new(big.Int).Set(counts[ci]),
+			index:     ci,
+		}
+		for _, wi := range c.order[i+1 : c.end[i]] {
+			step.Awarded = append(step.Awarded, cards[wi])
+			step.awarded = append(step.awarded, wi)
+		}
+		step.apply(counts)
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+func plural(n *big.Int, singular, plural string) string {
+	if n.IsInt64() && n.Int64() == 1 {
+		return singular
+	}
+	return plural
+}
+
+// WriteTrace writes a step-by-step account of the cascade of copies won by the
+// cards to w.
+func (cards Cards) WriteTrace(w io.Writer) error {
+	steps, err := cards.Trace()
+	if err != nil {
+		return err
+	}
+	var buf strings.Builder
+	counts := initialCounts(len(cards))
+	total := new(big.Int)
+	fmt.Fprintf(&buf, "Start with 1 instance of each of %d cards.\n", len(cards))
+	for _, step := range steps {
+		total.Add(total, step.Instances)
+		matches := big.NewInt(int64(len(step.Card.Matches)))
+		fmt.Fprintf(&buf, "Card %d has %s %s and %s %s: ",
+			step.Card.ID, matches, plural(matches, "match", "matches"),
+			step.Instances, plural(step.Instances, "instance", "instances"))
+		if len(step.Awarded) == 0 {
+			fmt.Fprintln(&buf, "it wins nothing.")
+			continue
+		}
+		ids := make([]string, len(step.Awarded))
+		for j, c := range step.Awarded {
+			ids[j] = fmt.Sprint(c.ID)
+		}
+		fmt.Fprintf(&buf, "it wins %s %s of cards %s.\n", step.Instances,
+			plural(step.Instances, "copy", "copies"), strings.Join(ids, ", "))
+		for j, c := range step.Awarded {
+			k := step.awarded[j]
+			before := counts[k].String()
+			counts[k].Add(counts[k], step.Instances)
+			fmt.Fprintf(&buf, "  Card %d: %s -> %s\n", c.ID, before, counts[k])
+		}
+	}
+	fmt.Fprintf(&buf, "Total: %s cards.\n", total)
+	_, err = io.WriteString(w, buf.String())
+	return err
+}
+
+// Dimensions and timing of the traced animation.
+const (
+	gifWidth  = 800
+	gifHeight = 240
+	gifDelay  = 50  // hundredths of a second per step
+	gifPause  = 300 // hundredths of a second on the final frame
+)
+
+var gifPalette = color.Palette{
+	color.White,
+	color.Gray{Y: 0xa0},                // instances of other cards
+	color.RGBA{0xd0, 0x30, 0x30, 0xff}, // the card being scratched
+	color.RGBA{0x30, 0xa0, 0x30, 0xff}, // cards being awarded copies
+}
+
+const (
+	gifBackground uint8 = iota
+	gifIdle
+	gifCurrent
+	gifAwarded
+)
+
+// frame draws the instances of each card as a bar chart, scaled so that top
+// is the full height. The bars of the card at index current and the cards at
+// the awarded indices are highlighted.
+func frame(counts []*big.Int, top *big.Int, current int, awarded []int) *image.Paletted {
+	ncards := len(counts)
+	bw := max(1, gifWidth/max(ncards, 1))
+	// Leave a gap between bars, if there is room for one.
+	var gap int
+	if bw > 2 {
+		gap = 1
+	}
+	img := image.NewPaletted(image.Rect(0, 0, bw*ncards, gifHeight), gifPalette)
+	var h big.Int
+	for i, n := range counts {
+		idx := gifIdle
+		switch {
+		case i == current:
+			idx = gifCurrent
+		case slices.Contains(awarded, i):
+			idx = gifAwarded
+		}
+		h.Mul(n, big.NewInt(gifHeight))
+		h.Quo(&h, top)
+		bh := max(1, int(h.Int64()))
+		for x := i * bw; x < (i+1)*bw-gap; x++ {
+			for y := gifHeight - bh; y < gifHeight; y++ {
+				img.SetColorIndex(x, y, idx)
+			}
+		}
+	}
+	return img
+}
+
+// WriteTraceGIF writes an animation of the cascade of copies won by the cards
+// to w, as a GIF. Each frame is a bar chart of the instances of every card,
+// with the card being scratched in red and the cards it awards copies of in
+// green.
+func (cards Cards) WriteTraceGIF(w io.Writer) error {
+	if len(cards) == 0 {
+		return errors.New("no cards to trace")
+	}
+	steps, err := cards.Trace()
+	if err != nil {
+		return err
+	}
+	// Replay the steps once to find the tallest bar, by which every frame is
+	// scaled, and again to draw the frames.
+	counts := initialCounts(len(cards))
+	for i := range steps {
+		steps[i].apply(counts)
+	}
+	top := big.NewInt(1)
+	for _, n := range counts {
+		if n.Cmp(top) > 0 {
+			top = n
+		}
+	}
+
+	anim := &gif.GIF{}
+	counts = initialCounts(len(cards))
+	for i := range steps {
+		steps[i].apply(counts)
+		anim.Image = append(anim.Image, frame(counts, top, steps[i].index, steps[i].awarded))
+		anim.Delay = append(anim.Delay, gifDelay)
+	}
+	anim.Image = append(anim.Image, frame(counts, top, -1, nil))
+	anim.Delay = append(anim.Delay, gifPause)
+	return gif.EncodeAll(w, anim)
+}
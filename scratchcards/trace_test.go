@@ -0,0 +1,123 @@
+package scratchcards
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/gif"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCardsWriteTrace(t *testing.T) {
+	type test struct {
+		cards Cards
+		want  string
+	}
+
+	for tn, tc := range map[string]test{
+		"zero": {
+			want: `Start with 1 instance of each of 0 cards.
+Total: 0 cards.
+`,
+		},
+		"out of order": {
+			cards: cardsWithMatches(2, 0, 1, 1),
+			want: `Start with 1 instance of each of 2 cards.
+Card 1 has 1 match and 1 instance: it wins 1 copy of cards 2.
+  Card 2: 1 -> 2
+Card 2 has 0 matches and 2 instances: it wins nothing.
+Total: 3 cards.
+`,
+		},
+		"example from problem": {
+			cards: cardsForTesting(t, exampleCards),
+			want: `Start with 1 instance of each of 6 cards.
+Card 1 has 4 matches and 1 instance: it wins 1 copy of cards 2, 3, 4, 5.
+  Card 2: 1 -> 2
+  Card 3: 1 -> 2
+  Card 4: 1 -> 2
+  Card 5: 1 -> 2
+Card 2 has 2 matches and 2 instances: it wins 2 copies of cards 3, 4.
+  Card 3: 2 -> 4
+  Card 4: 2 -> 4
+Card 3 has 2 matches and 4 instances: it wins 4 copies of cards 4, 5.
+  Card 4: 4 -> 8
+  Card 5: 2 -> 6
+Card 4 has 1 match and 8 instances: it wins 8 copies of cards 5.
+  Card 5: 6 -> 14
+Card 5 has 0 matches and 14 instances: it wins nothing.
+Card 6 has 0 matches and 1 instance: it wins nothing.
+Total: 30 cards.
+`,
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				var buf bytes.Buffer
+				if err := tc.cards.WriteTrace(&buf); err != nil {
+					t.Fatalf("WriteTrace(): unexpected error: %v", err)
+				}
+				if diff := cmp.Diff(buf.String(), tc.want); diff != "" {
+					t.Errorf("WriteTrace(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestCardsTraceReplay(t *testing.T) {
+	cards := cardsForTesting(t, exampleCards)
+	steps, err := cards.Trace()
+	if err != nil {
+		t.Fatalf("Trace(): unexpected error: %v", err)
+	}
+	counts := initialCounts(len(cards))
+	for i := range steps {
+		steps[i].apply(counts)
+	}
+	want, err := cards.Instances()
+	if err != nil {
+		t.Fatalf("Instances(): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(fmt.Sprint(counts), fmt.Sprint(want)); diff != "" {
+		t.Errorf("Trace(): replayed instances mismatch (-got,+want):\n%v", diff)
+	}
+}
+
+func TestCardsWriteTraceGIF(t *testing.T) {
+	cards := cardsForTesting(t, exampleCards)
+	var buf bytes.Buffer
+	if err := cards.WriteTraceGIF(&buf); err != nil {
+		t.Fatalf("WriteTraceGIF(): unexpected error: %v", err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("gif.DecodeAll(): unexpected error: %v", err)
+	}
+	// One frame per card, plus the final tally.
+	if got, want := len(anim.Image), len(cards)+1; got != want {
+		t.Errorf("WriteTraceGIF(): frame count mismatch: got: %d want: %d", got, want)
+	}
+	// The tallest bar is card 5, with 14 instances, which fills the frame.
+	img := anim.Image[len(anim.Image)-1]
+	x := 4*(gifWidth/len(cards)) + 1
+	if got := img.ColorIndexAt(x, 0); got != gifIdle {
+		t.Errorf("WriteTraceGIF(): top of tallest bar: got color %d want: %d", got, gifIdle)
+	}
+}
+
+func TestCardsWriteTraceDuplicate(t *testing.T) {
+	var buf bytes.Buffer
+	if err := cardsWithMatches(1, 0, 1, 0).WriteTrace(&buf); !errors.Is(err, ErrDuplicateCard) {
+		t.Errorf("WriteTrace(): error mismatch: got: %v want: %v", err, ErrDuplicateCard)
+	}
+}
+
+func TestCardsWriteTraceGIFEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Cards(nil).WriteTraceGIF(&buf); err == nil {
+		t.Error("WriteTraceGIF(): expected error for no cards")
+	}
+}
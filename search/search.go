@@ -0,0 +1,225 @@
+// Package search finds paths through graphs described implicitly by a start
+// node and a function yielding the neighbours of any node. Nodes may be of any
+// comparable type, such as a grid.Point or a *gondola.Cell.
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/cfunkhouser/aoc2023/util"
+)
+
+var (
+	// ErrNotFound is returned when no path reaches a goal.
+	ErrNotFound = errors.New("no path found")
+	// ErrNegativeCost is returned when a weighted search encounters an edge
+	// with a negative cost.
+	ErrNegativeCost = errors.New("negative edge cost")
+)
+
+// Edge to a neighbouring node, and the cost of traversing it.
+type Edge[N comparable] struct {
+	To   N
+	Cost int
+}
+
+// Path from the start node to a goal.
+type Path[N comparable] struct {
+	// Nodes on the path, beginning with the start and ending with the goal.
+	Nodes []N
+	// Cost of the path: its total edge cost for weighted searches, or its
+	// number of steps otherwise.
+	Cost int
+}
+
+// Goal returns a goal function satisfied only by n.
+func Goal[N comparable](n N) func(N) bool {
+	return func(o N) bool { return o == n }
+}
+
+// Unweighted adapts a neighbour function for use with weighted searches, with
+// every edge costing 1.
+func Unweighted[N comparable](neighbours func(N) []N) func(N) []Edge[N] {
+	return func(n N) []Edge[N] {
+		ns := neighbours(n)
+		ret := make([]Edge[N], len(ns))
+		for i, o := range ns {
+			ret[i] = Edge[N]{o, 1}
+		}
+		return ret
+	}
+}
+
+// checkEvery is how many nodes are expanded between checks for cancellation.
+const checkEvery = 256
+
+// canceller checks a context for cancellation periodically, rather than on
+// every expanded node.
+type canceller struct {
+	ctx context.Context
+	n   int
+}
+
+func (c *canceller) err() error {
+	if c.n++; c.n%checkEvery != 0 {
+		return nil
+	}
+	return c.ctx.Err()
+}
+
+// reconstruct the path ending at n by following parents back to the start.
+func reconstruct[N comparable](parents map[N]N, start, n N, cost int) Path[N] {
+	nodes := []N{n}
+	for n != start {
+		n = parents[n]
+		nodes = append(nodes, n)
+	}
+	slices.Reverse(nodes)
+	return Path[N]{Nodes: nodes, Cost: cost}
+}
+
+// BFS finds a path with the fewest steps from start to a node satisfying goal,
+// using breadth-first search.
+func BFS[N comparable](ctx context.Context, start N, goal func(N) bool, neighbours func(N) []N) (Path[N], error) {
+	c := &canceller{ctx: ctx}
+	parents := map[N]N{start: start}
+	for frontier, depth := []N{start}, 0; len(frontier) > 0; depth++ {
+		var next []N
+		for _, n := range frontier {
+			if err := c.err(); err != nil {
+				return Path[N]{}, err
+			}
+			if goal(n) {
+				return reconstruct(parents, start, n, depth), nil
+			}
+			for _, o := range neighbours(n) {
+				if _, seen := parents[o]; !seen {
+					parents[o] = n
+					next = append(next, o)
+				}
+			}
+		}
+		frontier = next
+	}
+	return Path[N]{}, ErrNotFound
+}
+
+// Distances from start to every node reachable from it, in steps, using
+// breadth-first search.
+func Distances[N comparable](ctx context.Context, start N, neighbours func(N) []N) (map[N]int, error) {
+	c := &canceller{ctx: ctx}
+	dist := map[N]int{start: 0}
+	for frontier, depth := []N{start}, 1; len(frontier) > 0; depth++ {
+		var next []N
+		for _, n := range frontier {
+			if err := c.err(); err != nil {
+				return nil, err
+			}
+			for _, o := range neighbours(n) {
+				if _, seen := dist[o]; !seen {
+					dist[o] = depth
+					next = append(next, o)
+				}
+			}
+		}
+		frontier = next
+	}
+	return dist, nil
+}
+
+// DFS finds a path from start to a node satisfying goal using depth-first
+// search. The path is not necessarily the shortest; neighbours are explored in
+// the order they are returned.
+func DFS[N comparable](ctx context.Context, start N, goal func(N) bool, neighbours func(N) []N) (Path[N], error) {
+	c := &canceller{ctx: ctx}
+	parents := map[N]N{start: start}
+	// The stack holds each node on the current path, along with the neighbours
+	// of it which remain to be explored.
+	type frame struct {
+		n    N
+		todo []N
+	}
+	if goal(start) {
+		return Path[N]{Nodes: []N{start}}, nil
+	}
+	stack := []frame{{start, neighbours(start)}}
+	for len(stack) > 0 {
+		if err := c.err(); err != nil {
+			return Path[N]{}, err
+		}
+		top := &stack[len(stack)-1]
+		if len(top.todo) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		o := top.todo[0]
+		top.todo = top.todo[1:]
+		if _, seen := parents[o]; seen {
+			continue
+		}
+		parents[o] = top.n
+		if goal(o) {
+			return reconstruct(parents, start, o, len(stack)), nil
+		}
+		stack = append(stack, frame{o, neighbours(o)})
+	}
+	return Path[N]{}, ErrNotFound
+}
+
+// Dijkstra finds a path of least cost from start to a node satisfying goal.
+// Edge costs must not be negative.
+func Dijkstra[N comparable](ctx context.Context, start N, goal func(N) bool, neighbours func(N) []Edge[N]) (Path[N], error) {
+	return AStar(ctx, start, goal, neighbours, func(N) int { return 0 })
+}
+
+// AStar finds a path of least cost from start to a node satisfying goal, guided
+// by a heuristic estimating the remaining cost from any node. The path found is
+// optimal as long as the heuristic never overestimates. Edge costs must not be
+// negative.
+func AStar[N comparable](ctx context.Context, start N, goal func(N) bool, neighbours func(N) []Edge[N], heuristic func(N) int) (Path[N], error) {
+	c := &canceller{ctx: ctx}
+	parents := map[N]N{start: start}
+	cost := map[N]int{start: 0}
+	open := util.NewPriorityQueue(func(a, b item[N]) bool { return a.priority < b.priority })
+	queued := map[N]*util.Item[item[N]]{start: open.Push(item[N]{node: start, priority: heuristic(start)})}
+	for open.Len() > 0 {
+		if err := c.err(); err != nil {
+			return Path[N]{}, err
+		}
+		it := open.Pop()
+		if goal(it.node) {
+			return reconstruct(parents, start, it.node, it.cost), nil
+		}
+		for _, e := range neighbours(it.node) {
+			if e.Cost < 0 {
+				return Path[N]{}, fmt.Errorf("%w: %d", ErrNegativeCost, e.Cost)
+			}
+			nc := it.cost + e.Cost
+			if oc, seen := cost[e.To]; seen && oc <= nc {
+				continue
+			}
+			cost[e.To] = nc
+			parents[e.To] = it.node
+			next := item[N]{node: e.To, cost: nc, priority: nc + heuristic(e.To)}
+			// Decrease the key of a node already in the queue; otherwise queue
+			// it, perhaps again, if the heuristic was inconsistent.
+			if h, ok := queued[e.To]; ok && h.Queued() {
+				open.Update(h, next)
+			} else {
+				queued[e.To] = open.Push(next)
+			}
+		}
+	}
+	return Path[N]{}, ErrNotFound
+}
+
+// item in the open set of a weighted search.
+type item[N comparable] struct {
+	node N
+	// cost of the path to node, and its priority: the cost plus the heuristic
+	// estimate of the cost remaining.
+	cost, priority int
+}
@@ -0,0 +1,205 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cfunkhouser/aoc2023/gondola"
+	"github.com/cfunkhouser/aoc2023/grid"
+	"github.com/google/go-cmp/cmp"
+)
+
+var maze = grid.New([]string{
+	"S..#....",
+	".#.#.##.",
+	".#...#..",
+	".####.#.",
+	"......#E",
+}, '#')
+
+func mazeEnds(t *testing.T) (start, end grid.Point) {
+	t.Helper()
+	start, ok := maze.Find('S')
+	if !ok {
+		t.Fatal("maze has no start")
+	}
+	end, ok = maze.Find('E')
+	if !ok {
+		t.Fatal("maze has no end")
+	}
+	return
+}
+
+func open(r rune) bool {
+	return r != '#'
+}
+
+// checkPath verifies that each step of the path is between neighbours.
+func checkPath[N comparable](t *testing.T, p Path[N], start, end N, neighbours func(N) []N) {
+	t.Helper()
+	if len(p.Nodes) == 0 || p.Nodes[0] != start || p.Nodes[len(p.Nodes)-1] != end {
+		t.Fatalf("path %v does not run from %v to %v", p.Nodes, start, end)
+	}
+	for i := 1; i < len(p.Nodes); i++ {
+		var ok bool
+		for _, n := range neighbours(p.Nodes[i-1]) {
+			ok = ok || n == p.Nodes[i]
+		}
+		if !ok {
+			t.Errorf("path steps from %v to %v, which are not neighbours", p.Nodes[i-1], p.Nodes[i])
+		}
+	}
+}
+
+func TestMaze(t *testing.T) {
+	start, end := mazeEnds(t)
+	neighbours := maze.Passable(open)
+	weighted := Unweighted(neighbours)
+	manhattan := func(p grid.Point) int { return p.Manhattan(end) }
+	ctx := context.Background()
+
+	type test struct {
+		search   func() (Path[grid.Point], error)
+		shortest bool
+	}
+
+	for tn, tc := range map[string]test{
+		"bfs": {func() (Path[grid.Point], error) {
+			return BFS(ctx, start, Goal(end), neighbours)
+		}, true},
+		"dfs": {func() (Path[grid.Point], error) {
+			return DFS(ctx, start, Goal(end), neighbours)
+		}, false},
+		"dijkstra": {func() (Path[grid.Point], error) {
+			return Dijkstra(ctx, start, Goal(end), weighted)
+		}, true},
+		"astar": {func() (Path[grid.Point], error) {
+			return AStar(ctx, start, Goal(end), weighted, manhattan)
+		}, true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				p, err := tc.search()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				checkPath(t, p, start, end, neighbours)
+				if p.Cost != len(p.Nodes)-1 {
+					t.Errorf("Cost: got: %d want: %d", p.Cost, len(p.Nodes)-1)
+				}
+				if tc.shortest && p.Cost != 15 {
+					t.Errorf("Cost: got: %d want: 15", p.Cost)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestDistances(t *testing.T) {
+	start, end := mazeEnds(t)
+	dist, err := Distances(context.Background(), start, maze.Passable(open))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := dist[end]; got != 15 {
+		t.Errorf("Distances()[E]: got: %d want: 15", got)
+	}
+	if got := len(dist); got != 27 {
+		t.Errorf("len(Distances()): got: %d want: 27", got)
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	// The direct edge from a to d is more expensive than going around.
+	edges := map[string][]Edge[string]{
+		"a": {{"d", 10}, {"b", 1}},
+		"b": {{"c", 2}},
+		"c": {{"d", 3}},
+	}
+	neighbours := func(n string) []Edge[string] { return edges[n] }
+	p, err := Dijkstra(context.Background(), "a", Goal("d"), neighbours)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(p, Path[string]{Nodes: []string{"a", "b", "c", "d"}, Cost: 6}); diff != "" {
+		t.Errorf("Dijkstra(): mismatch (-got,+want):\n%v", diff)
+	}
+
+	edges["b"] = append(edges["b"], Edge[string]{"a", -1})
+	if _, err := Dijkstra(context.Background(), "a", Goal("d"), neighbours); !errors.Is(err, ErrNegativeCost) {
+		t.Errorf("Dijkstra() with negative cost: got error: %v want: %v", err, ErrNegativeCost)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	start, _ := mazeEnds(t)
+	neighbours := maze.Passable(open)
+	unreachable := Goal(grid.Point{X: 3, Y: 0})
+	ctx := context.Background()
+	if _, err := BFS(ctx, start, unreachable, neighbours); !errors.Is(err, ErrNotFound) {
+		t.Errorf("BFS(): got error: %v want: %v", err, ErrNotFound)
+	}
+	if _, err := DFS(ctx, start, unreachable, neighbours); !errors.Is(err, ErrNotFound) {
+		t.Errorf("DFS(): got error: %v want: %v", err, ErrNotFound)
+	}
+	if _, err := Dijkstra(ctx, start, unreachable, Unweighted(neighbours)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Dijkstra(): got error: %v want: %v", err, ErrNotFound)
+	}
+}
+
+func TestCancellation(t *testing.T) {
+	// An unbounded graph, which would never be exhausted.
+	line := func(n int) []int { return []int{n + 1, n - 1} }
+	never := func(int) bool { return false }
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := BFS(ctx, 0, never, line); !errors.Is(err, context.Canceled) {
+		t.Errorf("BFS(): got error: %v want: %v", err, context.Canceled)
+	}
+	if _, err := DFS(ctx, 0, never, line); !errors.Is(err, context.Canceled) {
+		t.Errorf("DFS(): got error: %v want: %v", err, context.Canceled)
+	}
+	if _, err := AStar(ctx, 0, never, Unweighted(line), func(int) int { return 0 }); !errors.Is(err, context.Canceled) {
+		t.Errorf("AStar(): got error: %v want: %v", err, context.Canceled)
+	}
+	if _, err := Distances(ctx, 0, line); !errors.Is(err, context.Canceled) {
+		t.Errorf("Distances(): got error: %v want: %v", err, context.Canceled)
+	}
+}
+
+func joinCells(cells []*gondola.Cell) string {
+	parts := make([]string, len(cells))
+	for i, c := range cells {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestGondolaCells(t *testing.T) {
+	g := gondola.GridFromDocument(bytes.NewBufferString(`467..114..
+...*......
+..35..633.
+......#...
+617*......
+.....+.58.
+..592.....
+......755.
+...$.*....
+.664.598..`))
+	start, end := g.At(6, 7), g.At(5, 9)
+	p, err := BFS(context.Background(), start, Goal(end), (*gondola.Cell).ValidAjacent)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkPath(t, p, start, end, (*gondola.Cell).ValidAjacent)
+	if diff := cmp.Diff(joinCells(p.Nodes), "755 * 598"); diff != "" {
+		t.Errorf("BFS(): mismatch (-got,+want):\n%v", diff)
+	}
+
+	if _, err := BFS(context.Background(), g.At(0, 0), Goal(end), (*gondola.Cell).ValidAjacent); !errors.Is(err, ErrNotFound) {
+		t.Errorf("BFS() from 467 to 598: got error: %v want: %v", err, ErrNotFound)
+	}
+}
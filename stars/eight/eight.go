@@ -3,14 +3,20 @@
 package eight
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
+	"github.com/cfunkhouser/aoc2023/scratchcards"
 	"github.com/spf13/cobra"
 )
 
 var (
 	filePath string
+	table    bool
+	trace    bool
+	gifPath  string
+	scoring  string
 
 	starCmd = &cobra.Command{
 		Use:     "eight",
@@ -19,17 +25,49 @@ var (
 		Long: `Calculate the total number of scratch off cards.
 
 If no value is provided for -f / --file the document is read from STDIN.
+Pass --table to print a per-card breakdown, with totals, instead. Or pass
+--trace to print a step-by-step account of the copies each card wins, and add
+--gif to also save it as an animated GIF.
+
+The table's points column scores cards by doubling for each match after the
+first, unless --scoring gives another rule, as for the seventh star.
 		`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if table && trace {
+				return errors.New("--table and --trace cannot be used together")
+			}
+			if gifPath != "" && !trace {
+				return errors.New("--gif requires --trace")
+			}
+			if cmd.Flags().Changed("scoring") && !table {
+				return errors.New("--scoring requires --table")
+			}
+			s, err := scratchcards.ParseScoring(scoring)
+			if err != nil {
+				return err
+			}
 			f := os.Stdin
 			if filePath != "" {
-				var err error
 				if f, err = os.Open(filePath); err != nil {
 					return err
 				}
 				defer f.Close()
 			}
-			fmt.Println(FromDocument(f).Count())
+			if table || trace {
+				cards, err := scratchcards.FromDocument(f)
+				if err != nil {
+					return err
+				}
+				if table {
+					return cards.WriteTable(os.Stdout, s)
+				}
+				return writeTrace(cards)
+			}
+			v, err := FromDocument(f)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
 			return nil
 		},
 	}
@@ -38,6 +76,32 @@ If no value is provided for -f / --file the document is read from STDIN.
 func init() {
 	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
 		"Path to the scratch card values. Optional.")
+	starCmd.Flags().BoolVar(&table, "table", false,
+		"Print a per-card breakdown table.")
+	starCmd.Flags().StringVar(&scoring, "scoring", "doubling",
+		"Scoring rule for --table: doubling, linear, fibonacci or an expression over m.")
+	starCmd.Flags().BoolVar(&trace, "trace", false,
+		"Print a step-by-step trace of the copies won by each card.")
+	starCmd.Flags().StringVar(&gifPath, "gif", "",
+		"With --trace, also write the trace as an animated GIF to this path.")
+}
+
+func writeTrace(cards scratchcards.Cards) error {
+	if err := cards.WriteTrace(os.Stdout); err != nil {
+		return err
+	}
+	if gifPath == "" {
+		return nil
+	}
+	f, err := os.Create(gifPath)
+	if err != nil {
+		return err
+	}
+	if err := cards.WriteTraceGIF(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
 }
 
 // RegisterOn the provided command.
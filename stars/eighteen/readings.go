@@ -0,0 +1,18 @@
+package eighteen
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/cfunkhouser/aoc2023/mirage"
+)
+
+// FromDocument sums the value before the first of each sequence of readings,
+// extrapolated using the method.
+func FromDocument(doc io.Reader, m mirage.Method) (*big.Int, error) {
+	seqs, err := mirage.FromDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	return mirage.SumPrev(seqs, m)
+}
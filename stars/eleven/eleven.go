@@ -0,0 +1,50 @@
+// Package eleven solves for the eleventh star in Advent of Code 2023.
+// See: https://adventofcode.com/2023/day/6
+package eleven
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	filePath string
+
+	starCmd = &cobra.Command{
+		Use:     "eleven",
+		Aliases: []string{"eleventh", "11"},
+		Short:   "Calculate the product of the ways to win each boat race.",
+		Long: `Calculate the product of the ways to win each boat race.
+
+If no value is provided for -f / --file the document is read from STDIN.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if filePath != "" {
+				var err error
+				if f, err = os.Open(filePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			v, err := FromDocument(f)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+)
+
+func init() {
+	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
+		"Path to the race times and record distances. Optional.")
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(starCmd)
+}
@@ -0,0 +1,22 @@
+package eleven
+
+import (
+	"io"
+
+	"github.com/cfunkhouser/aoc2023/boatrace"
+	"github.com/cfunkhouser/aoc2023/util"
+)
+
+// FromDocument calculates the product of the number of ways to beat the record
+// in each race.
+func FromDocument(doc io.Reader) (int, error) {
+	races, err := boatrace.FromDocument(doc)
+	if err != nil {
+		return 0, err
+	}
+	ways := make([]int, len(races))
+	for i, r := range races {
+		ways[i] = r.Ways()
+	}
+	return util.Product(ways), nil
+}
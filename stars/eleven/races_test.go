@@ -0,0 +1,35 @@
+package eleven
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFromDocument(t *testing.T) {
+	type test struct {
+		doc     string
+		want    int
+		wantErr bool
+	}
+
+	for tn, tc := range map[string]test{
+		"example from problem": {
+			doc: `Time:      7  15   30
+Distance:  9  40  200`,
+			want: 288,
+		},
+		"missing distances": {doc: "Time: 7 15 30", wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := FromDocument(bytes.NewBufferString(tc.doc))
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("FromDocument(): error mismatch: got: %v wantErr: %v", err, tc.wantErr)
+				}
+				if err == nil && got != tc.want {
+					t.Errorf("FromDocument(): mismatch: got: %d want: %d", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
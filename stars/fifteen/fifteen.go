@@ -0,0 +1,50 @@
+// Package fifteen solves for the fifteenth star in Advent of Code 2023.
+// See: https://adventofcode.com/2023/day/8
+package fifteen
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	filePath string
+
+	starCmd = &cobra.Command{
+		Use:     "fifteen",
+		Aliases: []string{"fifteenth", "15"},
+		Short:   "Count the steps to walk a desert network from AAA to ZZZ.",
+		Long: `Count the steps to walk a desert network from AAA to ZZZ.
+
+If no value is provided for -f / --file the document is read from STDIN.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if filePath != "" {
+				var err error
+				if f, err = os.Open(filePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			v, err := FromDocument(f)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+)
+
+func init() {
+	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
+		"Path to the instructions and network. Optional.")
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(starCmd)
+}
@@ -0,0 +1,16 @@
+package fifteen
+
+import (
+	"io"
+
+	"github.com/cfunkhouser/aoc2023/wasteland"
+)
+
+// FromDocument counts the steps needed to walk the network from AAA to ZZZ.
+func FromDocument(doc io.Reader) (int, error) {
+	n, err := wasteland.FromDocument(doc)
+	if err != nil {
+		return 0, err
+	}
+	return n.Walk("AAA", func(name string) bool { return name == "ZZZ" })
+}
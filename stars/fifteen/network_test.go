@@ -0,0 +1,50 @@
+package fifteen
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFromDocument(t *testing.T) {
+	type test struct {
+		doc     string
+		want    int
+		wantErr bool
+	}
+
+	for tn, tc := range map[string]test{
+		"first example from problem": {
+			doc: `RL
+
+AAA = (BBB, CCC)
+BBB = (DDD, EEE)
+CCC = (ZZZ, GGG)
+DDD = (DDD, DDD)
+EEE = (EEE, EEE)
+GGG = (GGG, GGG)
+ZZZ = (ZZZ, ZZZ)`,
+			want: 2,
+		},
+		"second example from problem": {
+			doc: `LLR
+
+AAA = (BBB, BBB)
+BBB = (AAA, ZZZ)
+ZZZ = (ZZZ, ZZZ)`,
+			want: 6,
+		},
+		"missing start": {doc: "L\n\nZZZ = (ZZZ, ZZZ)", wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := FromDocument(bytes.NewBufferString(tc.doc))
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("FromDocument(): error mismatch: got: %v wantErr: %v", err, tc.wantErr)
+				}
+				if err == nil && got != tc.want {
+					t.Errorf("FromDocument(): mismatch: got: %d want: %d", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
@@ -0,0 +1,69 @@
+// Package fourteen solves for the fourteenth star in Advent of Code 2023.
+// See: https://adventofcode.com/2023/day/7
+package fourteen
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cfunkhouser/aoc2023/camelcards"
+	"github.com/spf13/cobra"
+)
+
+var (
+	filePath string
+	table    bool
+
+	starCmd = &cobra.Command{
+		Use:     "fourteen",
+		Aliases: []string{"fourteenth", "14"},
+		Short:   "Calculate the total winnings of Camel Cards hands with jokers.",
+		Long: `Calculate the total winnings of Camel Cards hands with jokers.
+
+J cards are jokers, which act as whichever card makes the strongest kind of
+hand, but are the weakest card when comparing hands of the same kind.
+
+If no value is provided for -f / --file the document is read from STDIN.
+Pass --table to print every hand's type, rank and winnings instead.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if filePath != "" {
+				var err error
+				if f, err = os.Open(filePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			if table {
+				hands, err := camelcards.FromDocument(f)
+				if err != nil {
+					return err
+				}
+				ranked, err := rules.Rank(hands)
+				if err != nil {
+					return err
+				}
+				return camelcards.WriteTable(os.Stdout, ranked)
+			}
+			v, err := FromDocument(f)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+)
+
+func init() {
+	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
+		"Path to the hands and bids. Optional.")
+	starCmd.Flags().BoolVar(&table, "table", false,
+		"Print a per-hand breakdown table.")
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(starCmd)
+}
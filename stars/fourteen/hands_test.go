@@ -0,0 +1,39 @@
+package fourteen
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFromDocument(t *testing.T) {
+	type test struct {
+		doc     string
+		want    int
+		wantErr bool
+	}
+
+	for tn, tc := range map[string]test{
+		"zero": {},
+		"example from problem": {
+			doc: `32T3K 765
+T55J5 684
+KK677 28
+KTJJT 220
+QQQJA 483`,
+			want: 5905,
+		},
+		"invalid card": {doc: "32T3X 765", wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := FromDocument(bytes.NewBufferString(tc.doc))
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("FromDocument(): error mismatch: got: %v wantErr: %v", err, tc.wantErr)
+				}
+				if err == nil && got != tc.want {
+					t.Errorf("FromDocument(): mismatch: got: %d want: %d", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
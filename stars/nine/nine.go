@@ -0,0 +1,62 @@
+// Package nine solves for the ninth star in Advent of Code 2023.
+// See: https://adventofcode.com/2023/day/5
+package nine
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cfunkhouser/aoc2023/almanac"
+	"github.com/spf13/cobra"
+)
+
+var (
+	filePath string
+	trace    bool
+
+	starCmd = &cobra.Command{
+		Use:     "nine",
+		Aliases: []string{"ninth", "9"},
+		Short:   "Find the lowest location for any seed in an almanac.",
+		Long: `Find the lowest location for any seed in an almanac.
+
+If no value is provided for -f / --file the document is read from STDIN.
+Pass --trace to print each seed's value after each map instead.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if filePath != "" {
+				var err error
+				if f, err = os.Open(filePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			if trace {
+				a, err := almanac.FromDocument(f)
+				if err != nil {
+					return err
+				}
+				return a.WriteTrace(os.Stdout, a.SeedIntervals())
+			}
+			v, err := FromDocument(f)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+)
+
+func init() {
+	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
+		"Path to the almanac. Optional.")
+	starCmd.Flags().BoolVar(&trace, "trace", false,
+		"Print each seed's value after each map.")
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(starCmd)
+}
@@ -0,0 +1,18 @@
+package nine
+
+import (
+	"io"
+
+	"github.com/cfunkhouser/aoc2023/almanac"
+	"github.com/cfunkhouser/aoc2023/interval"
+)
+
+// FromDocument finds the lowest location to which any seed in the almanac
+// maps.
+func FromDocument(doc io.Reader) (int, error) {
+	a, err := almanac.FromDocument(doc)
+	if err != nil {
+		return 0, err
+	}
+	return a.LowestLocation(interval.NewSet(a.SeedIntervals()...))
+}
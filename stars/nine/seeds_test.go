@@ -0,0 +1,67 @@
+package nine
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFromDocument(t *testing.T) {
+	type test struct {
+		doc     string
+		want    int
+		wantErr bool
+	}
+
+	for tn, tc := range map[string]test{
+		"example from problem": {
+			doc: `seeds: 79 14 55 13
+
+seed-to-soil map:
+50 98 2
+52 50 48
+
+soil-to-fertilizer map:
+0 15 37
+37 52 2
+39 0 15
+
+fertilizer-to-water map:
+49 53 8
+0 11 42
+42 0 7
+57 7 4
+
+water-to-light map:
+88 18 7
+18 25 70
+
+light-to-temperature map:
+45 77 23
+81 45 19
+68 64 13
+
+temperature-to-humidity map:
+0 69 1
+1 0 69
+
+humidity-to-location map:
+60 56 37
+56 93 4`,
+			want: 35,
+		},
+		"no seeds":      {doc: "seeds:", wantErr: true},
+		"invalid range": {doc: "seeds: 1 2\n\nseed-to-soil map:\n1 2\n", wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := FromDocument(bytes.NewBufferString(tc.doc))
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("FromDocument(): error mismatch: got: %v wantErr: %v", err, tc.wantErr)
+				}
+				if err == nil && got != tc.want {
+					t.Errorf("FromDocument(): mismatch: got: %d want: %d", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
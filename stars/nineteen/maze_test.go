@@ -0,0 +1,46 @@
+package nineteen
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFromDocument(t *testing.T) {
+	type test struct {
+		doc     string
+		want    int
+		wantErr bool
+	}
+
+	for tn, tc := range map[string]test{
+		"simple example from problem": {
+			doc: `-L|F7
+7S-7|
+L|7||
+-L-J|
+L|-JF`,
+			want: 4,
+		},
+		"tangled example from problem": {
+			doc: `7-F7-
+.FJ|7
+SJLL7
+|F--J
+LJ.LJ`,
+			want: 8,
+		},
+		"no loop": {doc: "S-7\n..|", wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := FromDocument(bytes.NewBufferString(tc.doc))
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("FromDocument(): error mismatch: got: %v wantErr: %v", err, tc.wantErr)
+				}
+				if err == nil && got != tc.want {
+					t.Errorf("FromDocument(): mismatch: got: %d want: %d", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
@@ -0,0 +1,52 @@
+// Package nineteen solves for the nineteenth star in Advent of Code 2023.
+// See: https://adventofcode.com/2023/day/10
+package nineteen
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	filePath string
+
+	starCmd = &cobra.Command{
+		Use:     "nineteen",
+		Aliases: []string{"nineteenth", "19"},
+		Short:   "Find the farthest point along the loop of pipe from the animal.",
+		Long: `Find the farthest point along the loop of pipe from the animal.
+
+The shape of the pipe under the animal's tile is inferred from its neighbours.
+
+If no value is provided for -f / --file the document is read from STDIN.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if filePath != "" {
+				var err error
+				if f, err = os.Open(filePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			v, err := FromDocument(f)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+)
+
+func init() {
+	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
+		"Path to the maze of pipes. Optional.")
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(starCmd)
+}
@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/cfunkhouser/aoc2023/scratchcards"
 	"github.com/spf13/cobra"
 )
 
 var (
 	filePath string
+	table    bool
+	scoring  string
 
 	starCmd = &cobra.Command{
 		Use:     "seven",
@@ -19,6 +22,12 @@ var (
 		Long: `Calculate the point value of a stack of scratch cards.
 
 If no value is provided for -f / --file the document is read from STDIN.
+Pass --table to print a per-card breakdown, with totals, instead.
+
+Cards are scored by doubling for each match after the first, unless --scoring
+is one of "linear" (a point per match), "fibonacci" (the Fibonacci number of the
+match count) or an integer expression over the number of matches, m, such as
+"m*m+1". Expressions support + - * / % ^ and parentheses.
 		`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			f := os.Stdin
@@ -29,7 +38,22 @@ If no value is provided for -f / --file the document is read from STDIN.
 				}
 				defer f.Close()
 			}
-			fmt.Println(FromDocument(f))
+			s, err := scratchcards.ParseScoring(scoring)
+			if err != nil {
+				return err
+			}
+			if table {
+				cards, err := scratchcards.FromDocument(f)
+				if err != nil {
+					return err
+				}
+				return cards.WriteTable(os.Stdout, s)
+			}
+			v, err := FromDocument(f, s)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
 			return nil
 		},
 	}
@@ -38,6 +62,10 @@ If no value is provided for -f / --file the document is read from STDIN.
 func init() {
 	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
 		"Path to the scratch card values. Optional.")
+	starCmd.Flags().BoolVar(&table, "table", false,
+		"Print a per-card breakdown table.")
+	starCmd.Flags().StringVar(&scoring, "scoring", "doubling",
+		"Scoring rule: doubling, linear, fibonacci or an expression over m.")
 }
 
 // RegisterOn the provided command.
@@ -0,0 +1,18 @@
+package seventeen
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/cfunkhouser/aoc2023/mirage"
+)
+
+// FromDocument sums the next value of each sequence of readings, extrapolated
+// using the method.
+func FromDocument(doc io.Reader, m mirage.Method) (*big.Int, error) {
+	seqs, err := mirage.FromDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	return mirage.SumNext(seqs, m)
+}
@@ -0,0 +1,41 @@
+package seventeen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cfunkhouser/aoc2023/mirage"
+)
+
+func TestFromDocument(t *testing.T) {
+	type test struct {
+		doc     string
+		want    int64
+		wantErr bool
+	}
+
+	for tn, tc := range map[string]test{
+		"example from problem": {
+			doc: `0 3 6 9 12 15
+1 3 6 10 15 21
+10 13 16 21 30 45`,
+			want: 114,
+		},
+		"differences never reach zero": {doc: "1 2 4 8", wantErr: true},
+		"not a number":                 {doc: "1 2 x", wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				for _, m := range []mirage.Method{mirage.DifferenceTable, mirage.Lagrange} {
+					got, err := FromDocument(bytes.NewBufferString(tc.doc), m)
+					if (err != nil) != tc.wantErr {
+						t.Fatalf("FromDocument(%v): error mismatch: got: %v wantErr: %v", m, err, tc.wantErr)
+					}
+					if err == nil && (!got.IsInt64() || got.Int64() != tc.want) {
+						t.Errorf("FromDocument(%v): mismatch: got: %v want: %d", m, got, tc.want)
+					}
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
@@ -0,0 +1,63 @@
+// Package seventeen solves for the seventeenth star in Advent of Code 2023.
+// See: https://adventofcode.com/2023/day/9
+package seventeen
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cfunkhouser/aoc2023/mirage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	filePath string
+	lagrange bool
+
+	starCmd = &cobra.Command{
+		Use:     "seventeen",
+		Aliases: []string{"seventeenth", "17"},
+		Short:   "Sum the next values extrapolated from each sequence of readings.",
+		Long: `Sum the next values extrapolated from each sequence of readings.
+
+Values are extrapolated by extending each sequence's table of differences, and
+a sequence whose differences never reach all zeros is an error. Pass --lagrange
+to evaluate the Lagrange polynomial through each sequence instead, which gives
+the same result and is useful for cross-checking.
+
+If no value is provided for -f / --file the document is read from STDIN.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if filePath != "" {
+				var err error
+				if f, err = os.Open(filePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			m := mirage.DifferenceTable
+			if lagrange {
+				m = mirage.Lagrange
+			}
+			v, err := FromDocument(f, m)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+)
+
+func init() {
+	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
+		"Path to the sequences of readings. Optional.")
+	starCmd.Flags().BoolVar(&lagrange, "lagrange", false,
+		"Extrapolate using Lagrange interpolation instead of differences.")
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(starCmd)
+}
@@ -3,6 +3,7 @@
 package six
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -13,6 +14,9 @@ import (
 
 var (
 	filePath string
+	stream   bool
+	topology string
+	mask     string
 
 	starCmd = &cobra.Command{
 		Use:     "six",
@@ -21,6 +25,10 @@ var (
 		Long: `Calculate the sum of gear ratios from a gondola schematic.
 		
 If no value is provided for -f / --file the document is read from STDIN.
+Pass --stream to analyze very tall schematics without loading them entirely
+into memory. Pass --topology toroidal for schematics which wrap around at their
+edges. Pass --mask with the path to a document laid out like the schematic, in
+which # marks off-limits positions, to treat those positions as empty.
 		`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			f := os.Stdin
@@ -31,7 +39,29 @@ If no value is provided for -f / --file the document is read from STDIN.
 				}
 				defer f.Close()
 			}
-			fmt.Println(util.Sum(gondola.FromDocument(f).GearRatios()))
+			t, err := gondola.ParseTopology(topology)
+			if err != nil {
+				return err
+			}
+			if mask != "" {
+				if t, err = gondola.MaskedFromFile(t, mask); err != nil {
+					return err
+				}
+			}
+			if stream {
+				if t != gondola.Rectangular {
+					return errors.New("--stream only supports the rectangular topology, without a mask")
+				}
+				var sum int
+				if err := gondola.Stream(f, gondola.StreamHandler{
+					GearRatio: func(n int) { sum += n },
+				}); err != nil {
+					return err
+				}
+				fmt.Println(sum)
+				return nil
+			}
+			fmt.Println(util.Sum(gondola.FromDocument(f, gondola.WithTopology(t)).GearRatios()))
 			return nil
 		},
 	}
@@ -40,6 +70,12 @@ If no value is provided for -f / --file the document is read from STDIN.
 func init() {
 	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
 		"Path to the trebuchet calibration document. Optional.")
+	starCmd.Flags().BoolVar(&stream, "stream", false,
+		"Analyze the schematic a few rows at a time, using bounded memory.")
+	starCmd.Flags().StringVar(&topology, "topology", "rectangular",
+		"Topology of the schematic, either rectangular or toroidal.")
+	starCmd.Flags().StringVar(&mask, "mask", "",
+		"Path to a mask of off-limits positions in the schematic. Optional.")
 }
 
 // RegisterOn the provided command.
@@ -0,0 +1,29 @@
+package sixteen
+
+import (
+	"io"
+	"strings"
+
+	"github.com/cfunkhouser/aoc2023/wasteland"
+)
+
+// Analyze the ghosts starting at every node ending in A, and walking together
+// until all of them are on nodes ending in Z.
+func Analyze(doc io.Reader) (*wasteland.Analysis, error) {
+	n, err := wasteland.FromDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	return n.Haunt(
+		func(name string) bool { return strings.HasSuffix(name, "A") },
+		func(name string) bool { return strings.HasSuffix(name, "Z") })
+}
+
+// FromDocument counts the steps until every ghost is on a node ending in Z.
+func FromDocument(doc io.Reader) (int, error) {
+	a, err := Analyze(doc)
+	if err != nil {
+		return 0, err
+	}
+	return a.Steps, nil
+}
@@ -0,0 +1,62 @@
+// Package sixteen solves for the sixteenth star in Advent of Code 2023.
+// See: https://adventofcode.com/2023/day/8
+package sixteen
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	filePath string
+	report   bool
+
+	starCmd = &cobra.Command{
+		Use:     "sixteen",
+		Aliases: []string{"sixteenth", "16"},
+		Short:   "Count the steps until ghosts walking a desert network all reach Z.",
+		Long: `Count the steps until ghosts walking a desert network all reach Z.
+
+A ghost starts at every node ending in A. Each ghost's walk is analysed for the
+cycle it falls into, and the steps at which it reaches a node ending in Z. The
+cycles are combined using the LCM of their lengths when every ghost reaches Z
+only at the end of its cycle, and the Chinese Remainder Theorem otherwise.
+
+If no value is provided for -f / --file the document is read from STDIN.
+Pass --report to print each ghost's cycle and the method used instead.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if filePath != "" {
+				var err error
+				if f, err = os.Open(filePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			a, err := Analyze(f)
+			if err != nil {
+				return err
+			}
+			if report {
+				return a.WriteReport(os.Stdout)
+			}
+			fmt.Println(a.Steps)
+			return nil
+		},
+	}
+)
+
+func init() {
+	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
+		"Path to the instructions and network. Optional.")
+	starCmd.Flags().BoolVar(&report, "report", false,
+		"Print each ghost's cycle, and the method used to combine them.")
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(starCmd)
+}
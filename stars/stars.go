@@ -6,12 +6,26 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/cfunkhouser/aoc2023/stars/eight"
+	"github.com/cfunkhouser/aoc2023/stars/eighteen"
+	"github.com/cfunkhouser/aoc2023/stars/eleven"
+	"github.com/cfunkhouser/aoc2023/stars/fifteen"
 	"github.com/cfunkhouser/aoc2023/stars/five"
 	"github.com/cfunkhouser/aoc2023/stars/four"
+	"github.com/cfunkhouser/aoc2023/stars/fourteen"
+	"github.com/cfunkhouser/aoc2023/stars/nine"
+	"github.com/cfunkhouser/aoc2023/stars/nineteen"
 	"github.com/cfunkhouser/aoc2023/stars/one"
 	"github.com/cfunkhouser/aoc2023/stars/seven"
+	"github.com/cfunkhouser/aoc2023/stars/seventeen"
 	"github.com/cfunkhouser/aoc2023/stars/six"
+	"github.com/cfunkhouser/aoc2023/stars/sixteen"
+	"github.com/cfunkhouser/aoc2023/stars/ten"
+	"github.com/cfunkhouser/aoc2023/stars/thirteen"
 	"github.com/cfunkhouser/aoc2023/stars/three"
+	"github.com/cfunkhouser/aoc2023/stars/twelve"
+	"github.com/cfunkhouser/aoc2023/stars/twenty"
+	"github.com/cfunkhouser/aoc2023/stars/twentyone"
+	"github.com/cfunkhouser/aoc2023/stars/twentytwo"
 	"github.com/cfunkhouser/aoc2023/stars/two"
 )
 
@@ -30,6 +44,20 @@ func init() {
 	six.RegisterOn(starCmd)
 	seven.RegisterOn(starCmd)
 	eight.RegisterOn(starCmd)
+	nine.RegisterOn(starCmd)
+	ten.RegisterOn(starCmd)
+	eleven.RegisterOn(starCmd)
+	twelve.RegisterOn(starCmd)
+	thirteen.RegisterOn(starCmd)
+	fourteen.RegisterOn(starCmd)
+	fifteen.RegisterOn(starCmd)
+	sixteen.RegisterOn(starCmd)
+	seventeen.RegisterOn(starCmd)
+	eighteen.RegisterOn(starCmd)
+	nineteen.RegisterOn(starCmd)
+	twenty.RegisterOn(starCmd)
+	twentyone.RegisterOn(starCmd)
+	twentytwo.RegisterOn(starCmd)
 }
 
 // RegisterOn the provided command.
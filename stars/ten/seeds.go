@@ -0,0 +1,23 @@
+package ten
+
+import (
+	"io"
+
+	"github.com/cfunkhouser/aoc2023/almanac"
+)
+
+// FromDocument finds the lowest location to which any seed in the almanac's
+// seed ranges maps. Whole ranges are mapped at once, split wherever they cross
+// the boundaries of a map's ranges, so the time taken does not depend on the
+// number of seeds.
+func FromDocument(doc io.Reader) (int, error) {
+	a, err := almanac.FromDocument(doc)
+	if err != nil {
+		return 0, err
+	}
+	seeds, err := a.SeedRanges()
+	if err != nil {
+		return 0, err
+	}
+	return a.LowestLocation(seeds)
+}
@@ -0,0 +1,69 @@
+// Package ten solves for the tenth star in Advent of Code 2023.
+// See: https://adventofcode.com/2023/day/5
+package ten
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cfunkhouser/aoc2023/almanac"
+	"github.com/spf13/cobra"
+)
+
+var (
+	filePath string
+	trace    bool
+
+	starCmd = &cobra.Command{
+		Use:     "ten",
+		Aliases: []string{"tenth", "10"},
+		Short:   "Find the lowest location for any seed in an almanac's seed ranges.",
+		Long: `Find the lowest location for any seed in an almanac's seed ranges.
+
+The seeds line of the almanac is read as pairs of the start and length of each
+range of seeds.
+
+If no value is provided for -f / --file the document is read from STDIN.
+Pass --trace to print how each range of seeds is split by each map instead.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if filePath != "" {
+				var err error
+				if f, err = os.Open(filePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			if trace {
+				a, err := almanac.FromDocument(f)
+				if err != nil {
+					return err
+				}
+				seeds, err := a.SeedRanges()
+				if err != nil {
+					return err
+				}
+				return a.WriteTrace(os.Stdout, seeds.Intervals())
+			}
+			v, err := FromDocument(f)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+)
+
+func init() {
+	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
+		"Path to the almanac. Optional.")
+	starCmd.Flags().BoolVar(&trace, "trace", false,
+		"Print how each range of seeds is split by each map.")
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(starCmd)
+}
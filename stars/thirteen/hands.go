@@ -0,0 +1,24 @@
+package thirteen
+
+import (
+	"io"
+
+	"github.com/cfunkhouser/aoc2023/camelcards"
+)
+
+// rules by which hands are ranked.
+var rules = camelcards.Standard
+
+// FromDocument calculates the total winnings of every hand, ranked by
+// camelcards.Standard rules.
+func FromDocument(doc io.Reader) (int, error) {
+	hands, err := camelcards.FromDocument(doc)
+	if err != nil {
+		return 0, err
+	}
+	ranked, err := rules.Rank(hands)
+	if err != nil {
+		return 0, err
+	}
+	return camelcards.TotalWinnings(ranked), nil
+}
@@ -0,0 +1,17 @@
+package twelve
+
+import (
+	"io"
+
+	"github.com/cfunkhouser/aoc2023/boatrace"
+)
+
+// FromDocument calculates the number of ways to beat the record in the single
+// race described by the document, once the spaces between digits are ignored.
+func FromDocument(doc io.Reader) (int, error) {
+	race, err := boatrace.KernedFromDocument(doc)
+	if err != nil {
+		return 0, err
+	}
+	return race.Ways(), nil
+}
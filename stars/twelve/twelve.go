@@ -0,0 +1,52 @@
+// Package twelve solves for the twelfth star in Advent of Code 2023.
+// See: https://adventofcode.com/2023/day/6
+package twelve
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	filePath string
+
+	starCmd = &cobra.Command{
+		Use:     "twelve",
+		Aliases: []string{"twelfth", "12"},
+		Short:   "Calculate the ways to win a single boat race with kerned values.",
+		Long: `Calculate the ways to win a single boat race with kerned values.
+
+The spaces between the digits of the time and the record distance are ignored,
+so that the document describes one long race. If no value is provided for
+-f / --file the document is read from STDIN.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if filePath != "" {
+				var err error
+				if f, err = os.Open(filePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			v, err := FromDocument(f)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+)
+
+func init() {
+	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
+		"Path to the race times and record distances. Optional.")
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(starCmd)
+}
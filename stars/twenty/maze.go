@@ -0,0 +1,25 @@
+package twenty
+
+import (
+	"io"
+
+	"github.com/cfunkhouser/aoc2023/pipes"
+)
+
+// Loop of pipe through the start of the maze.
+func Loop(doc io.Reader) (*pipes.Loop, error) {
+	m, err := pipes.FromDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	return m.Loop()
+}
+
+// FromDocument counts the tiles enclosed by the loop of pipe through the start.
+func FromDocument(doc io.Reader) (int, error) {
+	l, err := Loop(doc)
+	if err != nil {
+		return 0, err
+	}
+	return l.Area()
+}
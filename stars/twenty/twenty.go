@@ -0,0 +1,68 @@
+// Package twenty solves for the twentieth star in Advent of Code 2023.
+// See: https://adventofcode.com/2023/day/10
+package twenty
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	filePath string
+	render   bool
+
+	starCmd = &cobra.Command{
+		Use:     "twenty",
+		Aliases: []string{"twentieth", "20"},
+		Short:   "Count the tiles enclosed by the loop of pipe around the animal.",
+		Long: `Count the tiles enclosed by the loop of pipe around the animal.
+
+The enclosed tiles are counted twice: by scanning each row for crossings of the
+loop, and by applying Pick's theorem to the area given by the shoelace formula.
+It is an error if the counts disagree.
+
+If no value is provided for -f / --file the document is read from STDIN.
+Pass --render to draw the loop, with the enclosed tiles marked I, before the
+count.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if filePath != "" {
+				var err error
+				if f, err = os.Open(filePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			l, err := Loop(f)
+			if err != nil {
+				return err
+			}
+			if render {
+				if err := l.Render(os.Stdout); err != nil {
+					return err
+				}
+			}
+			v, err := l.Area()
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+)
+
+func init() {
+	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
+		"Path to the maze of pipes. Optional.")
+	starCmd.Flags().BoolVar(&render, "render", false,
+		"Draw the loop and the tiles it encloses.")
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(starCmd)
+}
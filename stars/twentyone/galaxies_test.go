@@ -0,0 +1,45 @@
+package twentyone
+
+import (
+	"bytes"
+	"testing"
+)
+
+const example = `...#......
+.......#..
+#.........
+..........
+......#...
+.#........
+.........#
+..........
+.......#..
+#...#.....`
+
+func TestFromDocument(t *testing.T) {
+	type test struct {
+		doc     string
+		factor  int
+		want    int
+		wantErr bool
+	}
+
+	for tn, tc := range map[string]test{
+		"example from problem": {doc: example, factor: 2, want: 374},
+		"example at 10":        {doc: example, factor: 10, want: 1030},
+		"negative expansion":   {doc: example, factor: -1, wantErr: true},
+		"unknown character":    {doc: "#.\n.x", factor: 2, wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := FromDocument(bytes.NewBufferString(tc.doc), tc.factor)
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("FromDocument(): error mismatch: got: %v wantErr: %v", err, tc.wantErr)
+				}
+				if err == nil && got != tc.want {
+					t.Errorf("FromDocument(): mismatch: got: %d want: %d", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
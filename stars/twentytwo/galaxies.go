@@ -0,0 +1,21 @@
+package twentytwo
+
+import (
+	"io"
+
+	"github.com/cfunkhouser/aoc2023/cosmic"
+)
+
+// FromDocument sums the distances between every pair of galaxies, after each
+// empty row and column is expanded into factor of them.
+func FromDocument(doc io.Reader, factor int) (int, error) {
+	im, err := cosmic.FromDocument(doc)
+	if err != nil {
+		return 0, err
+	}
+	galaxies, err := im.Expand(factor)
+	if err != nil {
+		return 0, err
+	}
+	return cosmic.SumDistances(galaxies)
+}
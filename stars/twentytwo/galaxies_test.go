@@ -0,0 +1,45 @@
+package twentytwo
+
+import (
+	"bytes"
+	"testing"
+)
+
+const example = `...#......
+.......#..
+#.........
+..........
+......#...
+.#........
+.........#
+..........
+.......#..
+#...#.....`
+
+func TestFromDocument(t *testing.T) {
+	type test struct {
+		doc     string
+		factor  int
+		want    int
+		wantErr bool
+	}
+
+	for tn, tc := range map[string]test{
+		"example from problem": {doc: example, factor: 1000000, want: 82000210},
+		"example at 100":       {doc: example, factor: 100, want: 8410},
+		"negative expansion":   {doc: example, factor: -1, wantErr: true},
+		"unknown character":    {doc: "#.\n.x", factor: 1000000, wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := FromDocument(bytes.NewBufferString(tc.doc), tc.factor)
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("FromDocument(): error mismatch: got: %v wantErr: %v", err, tc.wantErr)
+				}
+				if err == nil && got != tc.want {
+					t.Errorf("FromDocument(): mismatch: got: %d want: %d", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
@@ -0,0 +1,58 @@
+// Package twentytwo solves for the twenty-second star in Advent of Code 2023.
+// See: https://adventofcode.com/2023/day/11
+package twentytwo
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	filePath  string
+	expansion int
+
+	starCmd = &cobra.Command{
+		Use:     "twentytwo",
+		Aliases: []string{"twenty-second", "22"},
+		Short:   "Sum the distances between galaxies in the expanded universe.",
+		Long: `Sum the distances between galaxies in the expanded universe.
+
+Each row and column without a galaxy is replaced by --expansion of them, so 1
+leaves the universe as it is and 0 removes the empty rows and columns. The
+distances are summed along each axis using prefix sums, rather than by
+visiting every pair of galaxies.
+
+If no value is provided for -f / --file the document is read from STDIN.
+		`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f := os.Stdin
+			if filePath != "" {
+				var err error
+				if f, err = os.Open(filePath); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			v, err := FromDocument(f, expansion)
+			if err != nil {
+				return err
+			}
+			fmt.Println(v)
+			return nil
+		},
+	}
+)
+
+func init() {
+	starCmd.Flags().StringVarP(&filePath, "file", "f", "",
+		"Path to the image of the universe. Optional.")
+	starCmd.Flags().IntVar(&expansion, "expansion", 1000000,
+		"Number of rows or columns each empty one expands into.")
+}
+
+// RegisterOn the provided command.
+func RegisterOn(cmd *cobra.Command) {
+	cmd.AddCommand(starCmd)
+}
@@ -0,0 +1,141 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseError reports text which could not be parsed as an integer, along with
+// its position in the input.
+type ParseError struct {
+	// Offset in bytes of Text from the start of the input.
+	Offset int
+	Text   string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("offset %d: invalid integer %q: %v", e.Offset, e.Text, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// IntSpan is an integer found in a string, along with its position. The
+// integer's text is s[Start:End].
+type IntSpan struct {
+	Value      int
+	Start, End int
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// atoi parses s[start:end] as an integer, reporting any error at its position.
+func atoi(s string, start, end int) (int, error) {
+	n, err := strconv.Atoi(s[start:end])
+	if err != nil {
+		if ne, ok := err.(*strconv.NumError); ok {
+			err = ne.Err
+		}
+		return 0, &ParseError{Offset: start, Text: s[start:end], Err: err}
+	}
+	return n, nil
+}
+
+// ScanInts finds every run of decimal digits in s, ignoring anything else. If
+// signed is true, a '-' immediately before the digits negates them, unless it
+// directly follows another digit, so that "10-20" yields 10 and 20.
+func ScanInts(s string, signed bool) (ret []IntSpan, err error) {
+	for i := 0; i < len(s); {
+		if !isDigit(s[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		if signed && start > 0 && s[start-1] == '-' && (start < 2 || !isDigit(s[start-2])) {
+			start--
+		}
+		n, err := atoi(s, start, i)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, IntSpan{Value: n, Start: start, End: i})
+	}
+	return
+}
+
+// Ints extracts every signed integer from s, ignoring anything else. See
+// ScanInts for how signs are recognized.
+func Ints(s string) (ret []int, err error) {
+	spans, err := ScanInts(s, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, span := range spans {
+		ret = append(ret, span.Value)
+	}
+	return
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f'
+}
+
+// FieldInts parses each whitespace-separated field of s as a signed integer.
+// Unlike Ints, any field which is not an integer is an error.
+func FieldInts(s string) (ret []int, err error) {
+	for i := 0; i < len(s); {
+		if isSpace(s[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(s) && !isSpace(s[i]) {
+			i++
+		}
+		n, err := atoi(s, start, i)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, n)
+	}
+	return
+}
+
+// SplitInts parses a list of signed integers separated by sep, such as
+// "1, 2, 3". Whitespace around each integer is ignored. An empty string is an
+// empty list.
+func SplitInts(s, sep string) (ret []int, err error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	for start := 0; ; {
+		end := len(s)
+		if i := strings.Index(s[start:], sep); i >= 0 {
+			end = start + i
+		}
+		lo, hi := start, end
+		for lo < hi && isSpace(s[lo]) {
+			lo++
+		}
+		for hi > lo && isSpace(s[hi-1]) {
+			hi--
+		}
+		n, err := atoi(s, lo, hi)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, n)
+		if end == len(s) {
+			return ret, nil
+		}
+		start = end + len(sep)
+	}
+}
@@ -0,0 +1,171 @@
+package util
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScanInts(t *testing.T) {
+	type test struct {
+		s      string
+		signed bool
+		want   []IntSpan
+	}
+
+	for tn, tc := range map[string]test{
+		"zero": {},
+		"unsigned": {
+			s:    "467..-114.",
+			want: []IntSpan{{467, 0, 3}, {114, 6, 9}},
+		},
+		"signed": {
+			s:      "467..-114.",
+			signed: true,
+			want:   []IntSpan{{467, 0, 3}, {-114, 5, 9}},
+		},
+		"sign at start": {
+			s:      "-1 2",
+			signed: true,
+			want:   []IntSpan{{-1, 0, 2}, {2, 3, 4}},
+		},
+		"range is not negative": {
+			s:      "10-20",
+			signed: true,
+			want:   []IntSpan{{10, 0, 2}, {20, 3, 5}},
+		},
+		"double minus": {
+			s:      "--5",
+			signed: true,
+			want:   []IntSpan{{-5, 1, 3}},
+		},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := ScanInts(tc.s, tc.signed)
+				if err != nil {
+					t.Fatalf("ScanInts(): unexpected error: %v", err)
+				}
+				if diff := cmp.Diff(got, tc.want); diff != "" {
+					t.Errorf("ScanInts(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestInts(t *testing.T) {
+	type test struct {
+		s    string
+		want []int
+	}
+
+	for tn, tc := range map[string]test{
+		"zero":       {},
+		"almanac":    {"seeds: 79 14 55 13", []int{79, 14, 55, 13}},
+		"negatives":  {"0 -3 6 -9", []int{0, -3, 6, -9}},
+		"card":       {"Card 1: 41 48 | 83 86", []int{1, 41, 48, 83, 86}},
+		"no numbers": {"abc", nil},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := Ints(tc.s)
+				if err != nil {
+					t.Fatalf("Ints(): unexpected error: %v", err)
+				}
+				if diff := cmp.Diff(got, tc.want); diff != "" {
+					t.Errorf("Ints(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestFieldInts(t *testing.T) {
+	type test struct {
+		s          string
+		want       []int
+		wantOffset int
+		wantErr    bool
+	}
+
+	for tn, tc := range map[string]test{
+		"zero":       {},
+		"fields":     {s: " 83 86  6\t-31 ", want: []int{83, 86, 6, -31}},
+		"non-number": {s: "1 2x 3", wantOffset: 2, wantErr: true},
+		"overflow":   {s: "1 99999999999999999999", wantOffset: 2, wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := FieldInts(tc.s)
+				checkParseError(t, err, tc.wantErr, tc.wantOffset)
+				if diff := cmp.Diff(got, tc.want); diff != "" {
+					t.Errorf("FieldInts(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestSplitInts(t *testing.T) {
+	type test struct {
+		s, sep     string
+		want       []int
+		wantOffset int
+		wantErr    bool
+	}
+
+	for tn, tc := range map[string]test{
+		"zero":            {sep: ","},
+		"blank":           {s: "  ", sep: ","},
+		"commas":          {s: "1, 2 ,-3", sep: ",", want: []int{1, 2, -3}},
+		"multi-byte sep":  {s: "1 -> 2 -> 3", sep: "->", want: []int{1, 2, 3}},
+		"single":          {s: "42", sep: ",", want: []int{42}},
+		"empty element":   {s: "1,,3", sep: ",", wantOffset: 2, wantErr: true},
+		"trailing sep":    {s: "1,2,", sep: ",", wantOffset: 4, wantErr: true},
+		"invalid element": {s: "1, two", sep: ",", wantOffset: 3, wantErr: true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := SplitInts(tc.s, tc.sep)
+				checkParseError(t, err, tc.wantErr, tc.wantOffset)
+				if diff := cmp.Diff(got, tc.want); diff != "" {
+					t.Errorf("SplitInts(): mismatch (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func checkParseError(t *testing.T, err error, wantErr bool, wantOffset int) {
+	t.Helper()
+	if (err != nil) != wantErr {
+		t.Fatalf("error mismatch: got: %v wantErr: %v", err, wantErr)
+	}
+	if err == nil {
+		return
+	}
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("error %v is not a *ParseError", err)
+	}
+	if pe.Offset != wantOffset {
+		t.Errorf("ParseError offset mismatch: got: %d want: %d", pe.Offset, wantOffset)
+	}
+}
+
+func TestParseErrorUnwrap(t *testing.T) {
+	_, err := Ints("99999999999999999999")
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("Ints(): error %v does not wrap strconv.ErrRange", err)
+	}
+}
+
+func BenchmarkInts(b *testing.B) {
+	s := "Card   1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53"
+	for i := 0; i < b.N; i++ {
+		_, _ = Ints(s)
+	}
+}
@@ -0,0 +1,267 @@
+package util
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// Integer is any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Product of all elements in a slice of numeric values. The product of an
+// empty slice is 1.
+func Product[N Number](s []N) N {
+	ret := N(1)
+	for _, v := range s {
+		ret *= v
+	}
+	return ret
+}
+
+// Min of all elements in a slice of numeric values. Panics if s is empty.
+func Min[N Number](s []N) N {
+	ret := s[0]
+	for _, v := range s[1:] {
+		ret = min(ret, v)
+	}
+	return ret
+}
+
+// Max of all elements in a slice of numeric values. Panics if s is empty.
+func Max[N Number](s []N) N {
+	ret := s[0]
+	for _, v := range s[1:] {
+		ret = max(ret, v)
+	}
+	return ret
+}
+
+func abs[I Integer](v I) I {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// GCD is the greatest common divisor of all values, which is always
+// non-negative. The GCD of no values, or of only zeros, is 0.
+func GCD[I Integer](vs ...I) (ret I) {
+	for _, v := range vs {
+		a, b := abs(ret), abs(v)
+		for b != 0 {
+			a, b = b, a%b
+		}
+		ret = a
+	}
+	return
+}
+
+// LCM is the least common multiple of all values, which is always
+// non-negative. The LCM of no values is 1, and of any zero is 0. The result
+// overflows silently; see MulChecked.
+func LCM[I Integer](vs ...I) I {
+	ret := I(1)
+	for _, v := range vs {
+		if v == 0 {
+			return 0
+		}
+		ret = ret / GCD(ret, v) * abs(v)
+	}
+	return ret
+}
+
+// isSigned is true if I is a signed integer type.
+func isSigned[I Integer]() bool {
+	var zero I
+	return zero-1 < zero
+}
+
+// AddChecked returns a + b, and whether the sum fits in I.
+func AddChecked[I Integer](a, b I) (I, bool) {
+	s := a + b
+	if isSigned[I]() {
+		return s, (b >= 0) == (s >= a)
+	}
+	return s, s >= a
+}
+
+// MulChecked returns a * b, and whether the product fits in I.
+func MulChecked[I Integer](a, b I) (I, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	p := a * b
+	if isSigned[I]() {
+		// The most negative value cannot be negated, and dividing it by -1
+		// overflows, so check for it before dividing below.
+		var zero I
+		if (a == zero-1 && b < 0 && -b < 0) || (b == zero-1 && a < 0 && -a < 0) {
+			return p, false
+		}
+	}
+	return p, p/b == a
+}
+
+// toBig converts any integer to a big.Int.
+func toBig[I Integer](v I) *big.Int {
+	if v < 0 {
+		return big.NewInt(int64(v))
+	}
+	return new(big.Int).SetUint64(uint64(v))
+}
+
+// fromBig converts a big.Int to I, reporting whether it fits.
+func fromBig[I Integer](v *big.Int) (I, bool) {
+	if v.IsInt64() {
+		n := v.Int64()
+		return I(n), n == int64(I(n)) && (n >= 0 || isSigned[I]())
+	}
+	if v.IsUint64() {
+		n := v.Uint64()
+		return I(n), n == uint64(I(n)) && I(n) >= 0
+	}
+	return 0, false
+}
+
+// ModPow is base raised to the power exp, modulo mod. The result is in the
+// range [0, mod). Panics if exp is negative or mod is not positive.
+func ModPow[I Integer](base, exp, mod I) I {
+	if exp < 0 {
+		panic("util.ModPow: negative exponent")
+	}
+	if mod <= 0 {
+		panic("util.ModPow: non-positive modulus")
+	}
+	if base %= mod; base < 0 {
+		base += mod
+	}
+	m, b, e := uint64(mod), uint64(base), uint64(exp)
+	ret := uint64(1) % m
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			ret = mulMod(ret, b, m)
+		}
+		b = mulMod(b, b, m)
+	}
+	return I(ret)
+}
+
+// mulMod is a * b mod m, without overflow.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// ModInverse of a modulo mod: the x in [0, mod) for which a * x is congruent to
+// 1. The result is only valid if ok is true; no inverse exists unless a and mod
+// are coprime. Panics if mod is not positive.
+func ModInverse[I Integer](a, mod I) (x I, ok bool) {
+	if mod <= 0 {
+		panic("util.ModInverse: non-positive modulus")
+	}
+	m := toBig(mod)
+	r := new(big.Int).Mod(toBig(a), m)
+	if m.Cmp(big.NewInt(1)) == 0 {
+		return 0, true
+	}
+	if r.ModInverse(r, m) == nil {
+		return 0, false
+	}
+	return fromBig[I](r)
+}
+
+var (
+	// ErrNoSolution is returned by CRT when the congruences are inconsistent.
+	ErrNoSolution = errors.New("no solution")
+	// ErrOverflow is returned when a result does not fit in its type.
+	ErrOverflow = errors.New("overflow")
+)
+
+// CRT solves the system of congruences x ≡ residues[i] (mod moduli[i]) using
+// the Chinese Remainder Theorem. The moduli need not be pairwise coprime. The
+// solution is the smallest non-negative x, along with the modulus m, the LCM of
+// the moduli, so that every solution is x + k*m. Returns ErrNoSolution if the
+// congruences are inconsistent, or ErrOverflow if m does not fit in I. Panics
+// if the slices differ in length or any modulus is not positive.
+func CRT[I Integer](residues, moduli []I) (x, m I, err error) {
+	if len(residues) != len(moduli) {
+		panic("util.CRT: residues and moduli differ in length")
+	}
+	bx, bm := big.NewInt(0), big.NewInt(1)
+	var g, p, q, diff big.Int
+	for i, mod := range moduli {
+		if mod <= 0 {
+			panic("util.CRT: non-positive modulus")
+		}
+		r, n := toBig(residues[i]), toBig(mod)
+		r.Mod(r, n)
+		// Solve bx + bm*k ≡ r (mod n) for k.
+		g.GCD(&p, &q, bm, n)
+		diff.Sub(r, bx)
+		if new(big.Int).Mod(&diff, &g).Sign() != 0 {
+			return 0, 0, ErrNoSolution
+		}
+		step := new(big.Int).Quo(n, &g)
+		k := diff.Quo(&diff, &g)
+		k.Mul(k, &p)
+		k.Mod(k, step)
+		bx.Add(bx, k.Mul(k, bm))
+		bm.Mul(bm, step)
+		bx.Mod(bx, bm)
+	}
+	var ok bool
+	if m, ok = fromBig[I](bm); !ok {
+		return 0, 0, ErrOverflow
+	}
+	x, _ = fromBig[I](bx)
+	return x, m, nil
+}
+
+// ISqrt is the integer square root of n: the largest r for which r*r <= n.
+// Panics if n is negative.
+func ISqrt[I Integer](n I) I {
+	if n < 0 {
+		panic("util.ISqrt: negative argument")
+	}
+	v := uint64(n)
+	r := uint64(math.Sqrt(float64(v)))
+	// The floating point estimate may be off by one in either direction for
+	// large values, so correct it exactly.
+	exceeds := func(r uint64) bool {
+		hi, lo := bits.Mul64(r, r)
+		return hi > 0 || lo > v
+	}
+	for exceeds(r) {
+		r--
+	}
+	for !exceeds(r + 1) {
+		r++
+	}
+	return I(r)
+}
+
+// Binomial coefficient n choose k, and whether it fits in I. It is 0 if k is
+// negative or greater than n.
+func Binomial[I Integer](n, k I) (I, bool) {
+	if k < 0 || k > n {
+		return 0, true
+	}
+	k = min(k, n-k)
+	ret := I(1)
+	for i := I(1); i <= k; i++ {
+		// ret * (n-k+i) is divisible by i, so divide by their GCD first to
+		// avoid spurious overflow.
+		g := GCD(ret, i)
+		p, ok := MulChecked(ret/g, (n-k+i)/(i/g))
+		if !ok {
+			return p, false
+		}
+		ret = p
+	}
+	return ret, true
+}
@@ -0,0 +1,205 @@
+package util
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestProductMinMax(t *testing.T) {
+	if got := Product([]int{2, 3, 7}); got != 42 {
+		t.Errorf("Product(): got: %v want: 42", got)
+	}
+	if got := Product([]float64(nil)); got != 1 {
+		t.Errorf("Product(nil): got: %v want: 1", got)
+	}
+	if got := Min([]int{4, -2, 9}); got != -2 {
+		t.Errorf("Min(): got: %v want: -2", got)
+	}
+	if got := Max([]uint8{4, 2, 9}); got != 9 {
+		t.Errorf("Max(): got: %v want: 9", got)
+	}
+}
+
+func TestGCDLCM(t *testing.T) {
+	type test struct {
+		vs       []int
+		gcd, lcm int
+	}
+
+	for tn, tc := range map[string]test{
+		"none":     {nil, 0, 1},
+		"single":   {[]int{-6}, 6, 6},
+		"coprime":  {[]int{4, 9}, 1, 36},
+		"shared":   {[]int{12, 18, 30}, 6, 180},
+		"negative": {[]int{-4, 6}, 2, 12},
+		"zero":     {[]int{0, 5}, 5, 0},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				if got := GCD(tc.vs...); got != tc.gcd {
+					t.Errorf("GCD(%v): got: %v want: %v", tc.vs, got, tc.gcd)
+				}
+				if got := LCM(tc.vs...); got != tc.lcm {
+					t.Errorf("LCM(%v): got: %v want: %v", tc.vs, got, tc.lcm)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestAddChecked(t *testing.T) {
+	if got, ok := AddChecked(math.MaxInt64-1, 1); !ok || got != math.MaxInt64 {
+		t.Errorf("AddChecked(MaxInt64-1, 1): got: %v, %v", got, ok)
+	}
+	if _, ok := AddChecked(int64(math.MaxInt64), 1); ok {
+		t.Errorf("AddChecked(MaxInt64, 1): want overflow")
+	}
+	if _, ok := AddChecked(int8(-100), -100); ok {
+		t.Errorf("AddChecked(int8(-100), -100): want overflow")
+	}
+	if _, ok := AddChecked(uint8(200), 100); ok {
+		t.Errorf("AddChecked(uint8(200), 100): want overflow")
+	}
+	if got, ok := AddChecked(uint8(200), 55); !ok || got != 255 {
+		t.Errorf("AddChecked(uint8(200), 55): got: %v, %v", got, ok)
+	}
+}
+
+func TestMulChecked(t *testing.T) {
+	type test struct {
+		a, b int64
+		ok   bool
+	}
+
+	for tn, tc := range map[string]test{
+		"zero":           {0, math.MinInt64, true},
+		"small":          {-3, 7, true},
+		"max":            {math.MaxInt64, 1, true},
+		"overflow":       {math.MaxInt64, 2, false},
+		"min by 2":       {math.MinInt64, 2, false},
+		"min by -1":      {math.MinInt64, -1, false},
+		"-1 by min":      {-1, math.MinInt64, false},
+		"-1 by max":      {-1, math.MaxInt64, true},
+		"large negative": {-(1 << 32), 1 << 31, true},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, ok := MulChecked(tc.a, tc.b)
+				if ok != tc.ok {
+					t.Errorf("MulChecked(%d, %d): got ok: %v want: %v", tc.a, tc.b, ok, tc.ok)
+				}
+				if ok && got != tc.a*tc.b {
+					t.Errorf("MulChecked(%d, %d): got: %d want: %d", tc.a, tc.b, got, tc.a*tc.b)
+				}
+			})
+		}(t, tn, &tc)
+	}
+	if _, ok := MulChecked(uint8(16), 16); ok {
+		t.Errorf("MulChecked(uint8(16), 16): want overflow")
+	}
+}
+
+func TestModPow(t *testing.T) {
+	type test struct {
+		base, exp, mod, want int64
+	}
+
+	for tn, tc := range map[string]test{
+		"simple":        {2, 10, 1000, 24},
+		"zero exponent": {5, 0, 7, 1},
+		"modulus one":   {5, 0, 1, 0},
+		"negative base": {-2, 3, 5, 2},
+		"fermat":        {3, 1_000_000_006, 1_000_000_007, 1},
+		"large modulus": {math.MaxInt64 - 1, 2, math.MaxInt64, 1},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				if got := ModPow(tc.base, tc.exp, tc.mod); got != tc.want {
+					t.Errorf("ModPow(%d, %d, %d): got: %d want: %d", tc.base, tc.exp, tc.mod, got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	if got, ok := ModInverse(3, 11); !ok || got != 4 {
+		t.Errorf("ModInverse(3, 11): got: %v, %v want: 4, true", got, ok)
+	}
+	if got, ok := ModInverse(-3, 11); !ok || got != 7 {
+		t.Errorf("ModInverse(-3, 11): got: %v, %v want: 7, true", got, ok)
+	}
+	if _, ok := ModInverse(6, 9); ok {
+		t.Errorf("ModInverse(6, 9): want no inverse")
+	}
+}
+
+func TestCRT(t *testing.T) {
+	type test struct {
+		residues, moduli []int
+		x, m             int
+		err              error
+	}
+
+	for tn, tc := range map[string]test{
+		"empty":        {nil, nil, 0, 1, nil},
+		"coprime":      {[]int{2, 3, 2}, []int{3, 5, 7}, 23, 105, nil},
+		"non-coprime":  {[]int{2, 8}, []int{6, 10}, 8, 30, nil},
+		"inconsistent": {[]int{1, 2}, []int{4, 6}, 0, 0, ErrNoSolution},
+		"negative":     {[]int{-1, -1}, []int{4, 6}, 11, 12, nil},
+		"overflow":     {[]int{0, 0}, []int{math.MaxInt64, math.MaxInt64 - 1}, 0, 0, ErrOverflow},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				x, m, err := CRT(tc.residues, tc.moduli)
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("CRT(): got error: %v want: %v", err, tc.err)
+				}
+				if x != tc.x || m != tc.m {
+					t.Errorf("CRT(): got: %d, %d want: %d, %d", x, m, tc.x, tc.m)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestISqrt(t *testing.T) {
+	for _, tc := range []struct{ n, want uint64 }{
+		{0, 0},
+		{1, 1},
+		{15, 3},
+		{16, 4},
+		{1<<52 + 1, 1 << 26},
+		{(1<<32 - 1) * (1<<32 - 1), 1<<32 - 1},
+		{math.MaxUint64, 1<<32 - 1},
+		{(1<<31+1)*(1<<31+1) - 1, 1 << 31},
+	} {
+		if got := ISqrt(tc.n); got != tc.want {
+			t.Errorf("ISqrt(%d): got: %d want: %d", tc.n, got, tc.want)
+		}
+	}
+	if got := ISqrt(int64(math.MaxInt64)); got != 3037000499 {
+		t.Errorf("ISqrt(MaxInt64): got: %d want: 3037000499", got)
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	for _, tc := range []struct {
+		n, k, want int64
+		ok         bool
+	}{
+		{5, 2, 10, true},
+		{5, 0, 1, true},
+		{5, 6, 0, true},
+		{5, -1, 0, true},
+		{52, 5, 2598960, true},
+		{66, 33, 7219428434016265740, true},
+		{68, 34, 0, false},
+	} {
+		got, ok := Binomial(tc.n, tc.k)
+		if ok != tc.ok || (ok && got != tc.want) {
+			t.Errorf("Binomial(%d, %d): got: %d, %v want: %d, %v", tc.n, tc.k, got, ok, tc.want, tc.ok)
+		}
+	}
+}
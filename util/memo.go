@@ -0,0 +1,165 @@
+package util
+
+import "container/list"
+
+// Memo caches the results of a recursive function by its argument. Functions
+// of several arguments can be memoised by gathering them into a comparable
+// struct.
+type Memo[K comparable, V any] struct {
+	f func(recurse func(K) V, k K) V
+	// limit on the number of cached results, or 0 for no limit.
+	limit   int
+	results map[K]*list.Element
+	// recent orders the cached entries from most to least recently used. It
+	// is only maintained when there is a limit.
+	recent *list.List
+}
+
+type memoEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewMemo for f, which must compute its result for k by calling recurse rather
+// than itself, so that the recursive calls are memoised too. Every result is
+// cached.
+func NewMemo[K comparable, V any](f func(recurse func(K) V, k K) V) *Memo[K, V] {
+	return NewBoundedMemo(0, f)
+}
+
+// NewBoundedMemo is NewMemo, but caching at most limit results. When full, the
+// least recently used result is evicted. A limit of 0 means no limit.
+func NewBoundedMemo[K comparable, V any](limit int, f func(recurse func(K) V, k K) V) *Memo[K, V] {
+	return &Memo[K, V]{
+		f:       f,
+		limit:   limit,
+		results: make(map[K]*list.Element),
+		recent:  list.New(),
+	}
+}
+
+// Memoize f, which must compute its result for k by calling recurse rather than
+// itself. See NewMemo.
+func Memoize[K comparable, V any](f func(recurse func(K) V, k K) V) func(K) V {
+	return NewMemo(f).Call
+}
+
+// Call the memoised function, returning the cached result for k if there is
+// one.
+func (m *Memo[K, V]) Call(k K) V {
+	if e, ok := m.results[k]; ok {
+		if m.limit > 0 {
+			m.recent.MoveToFront(e)
+		}
+		return e.Value.(*memoEntry[K, V]).value
+	}
+	v := m.f(m.Call, k)
+	if m.limit == 0 {
+		m.results[k] = &list.Element{Value: &memoEntry[K, V]{k, v}}
+		return v
+	}
+	// A recursive call may already have cached k.
+	if e, ok := m.results[k]; ok {
+		m.recent.MoveToFront(e)
+		return v
+	}
+	m.results[k] = m.recent.PushFront(&memoEntry[K, V]{k, v})
+	if m.recent.Len() > m.limit {
+		oldest := m.recent.Remove(m.recent.Back()).(*memoEntry[K, V])
+		delete(m.results, oldest.key)
+	}
+	return v
+}
+
+// Len is the number of cached results.
+func (m *Memo[K, V]) Len() int {
+	return len(m.results)
+}
+
+// Cycle in a sequence of states, each produced from the last by a function.
+// The state at step Start is the first to recur, Length steps later, and the
+// sequence repeats from then on.
+type Cycle struct {
+	Start, Length int
+}
+
+// Equivalent is the earliest step whose state is the same as that at step n,
+// which is always less than Start+Length. This is how to find the state after
+// an enormous number of steps.
+func (c Cycle) Equivalent(n int) int {
+	if n < c.Start {
+		return n
+	}
+	return c.Start + (n-c.Start)%c.Length
+}
+
+// Floyd finds the cycle in the sequence x0, f(x0), f(f(x0)), ... using Floyd's
+// tortoise and hare algorithm, in constant memory. The sequence must
+// eventually cycle, as it must if there are finitely many states, or Floyd
+// never returns.
+func Floyd[S comparable](x0 S, f func(S) S) Cycle {
+	// Find a step at which the hare, moving twice as fast, meets the tortoise
+	// within the cycle.
+	tortoise, hare := f(x0), f(f(x0))
+	for tortoise != hare {
+		tortoise, hare = f(tortoise), f(f(hare))
+	}
+	// The distance from there to the start of the cycle is the same as from
+	// x0 to it.
+	var c Cycle
+	tortoise = x0
+	for tortoise != hare {
+		tortoise, hare = f(tortoise), f(hare)
+		c.Start++
+	}
+	c.Length = 1
+	for hare = f(tortoise); tortoise != hare; hare = f(hare) {
+		c.Length++
+	}
+	return c
+}
+
+// Brent finds the cycle in the sequence x0, f(x0), f(f(x0)), ... using Brent's
+// algorithm, in constant memory and usually fewer calls to f than Floyd. The
+// sequence must eventually cycle, or Brent never returns.
+func Brent[S comparable](x0 S, f func(S) S) Cycle {
+	// Find the length by searching successive powers of two.
+	var c Cycle
+	power, tortoise, hare := 1, x0, f(x0)
+	for c.Length = 1; tortoise != hare; c.Length++ {
+		if power == c.Length {
+			tortoise = hare
+			power *= 2
+			c.Length = 0
+		}
+		hare = f(hare)
+	}
+	// Then find the start with two states Length steps apart.
+	tortoise, hare = x0, x0
+	for i := 0; i < c.Length; i++ {
+		hare = f(hare)
+	}
+	for tortoise != hare {
+		tortoise, hare = f(tortoise), f(hare)
+		c.Start++
+	}
+	return c
+}
+
+// FindCycle in the sequence x0, f(x0), f(f(x0)), ... by remembering the key of
+// every state, for states which are not themselves comparable or which are
+// expensive to compare. Two states are considered the same if their keys are.
+// Returns the states from step 0 up to, but not including, Start+Length, so
+// that the state at any step n is states[c.Equivalent(n)]. The sequence must
+// eventually cycle, or FindCycle never returns.
+func FindCycle[S any, K comparable](x0 S, f func(S) S, key func(S) K) (c Cycle, states []S) {
+	seen := make(map[K]int)
+	for x, i := x0, 0; ; x, i = f(x), i+1 {
+		k := key(x)
+		if start, ok := seen[k]; ok {
+			return Cycle{Start: start, Length: i - start}, states
+		}
+		seen[k] = i
+		states = append(states, x)
+	}
+}
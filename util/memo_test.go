@@ -0,0 +1,104 @@
+package util
+
+import (
+	"fmt"
+	"testing"
+)
+
+func fibonacci(recurse func(int) int, n int) int {
+	if n < 2 {
+		return n
+	}
+	return recurse(n-1) + recurse(n-2)
+}
+
+func TestMemoize(t *testing.T) {
+	var calls int
+	fib := Memoize(func(recurse func(int) int, n int) int {
+		calls++
+		return fibonacci(recurse, n)
+	})
+	if got := fib(90); got != 2880067194370816120 {
+		t.Errorf("fib(90): got: %v want: 2880067194370816120", got)
+	}
+	if calls != 91 {
+		t.Errorf("calls: got: %v want: 91", calls)
+	}
+	fib(90)
+	if calls != 91 {
+		t.Errorf("calls after repeating: got: %v want: 91", calls)
+	}
+}
+
+func TestBoundedMemo(t *testing.T) {
+	m := NewBoundedMemo(3, fibonacci)
+	if got := m.Call(40); got != 102334155 {
+		t.Errorf("Call(40): got: %v want: 102334155", got)
+	}
+	if m.Len() != 3 {
+		t.Errorf("Len(): got: %v want: 3", m.Len())
+	}
+
+	// Only the most recently used keys remain.
+	var calls []int
+	m = NewBoundedMemo(2, func(_ func(int) int, k int) int {
+		calls = append(calls, k)
+		return k
+	})
+	for _, k := range []int{1, 2, 1, 3, 2, 1} {
+		m.Call(k)
+	}
+	if got, want := fmt.Sprint(calls), "[1 2 3 2 1]"; got != want {
+		t.Errorf("calls: got: %v want: %v", got, want)
+	}
+}
+
+func TestCycles(t *testing.T) {
+	// 0 1 2 [3 4 5 6 7] 3 ...
+	f := func(x int) int {
+		if x == 7 {
+			return 3
+		}
+		return x + 1
+	}
+	want := Cycle{Start: 3, Length: 5}
+	if got := Floyd(0, f); got != want {
+		t.Errorf("Floyd(): got: %+v want: %+v", got, want)
+	}
+	if got := Brent(0, f); got != want {
+		t.Errorf("Brent(): got: %+v want: %+v", got, want)
+	}
+	got, states := FindCycle(0, f, func(x int) int { return x })
+	if got != want {
+		t.Errorf("FindCycle(): got: %+v want: %+v", got, want)
+	}
+	if len(states) != 8 {
+		t.Errorf("FindCycle() states: got: %v", states)
+	}
+	for n, want := range map[int]int{2: 2, 7: 7, 8: 3, 1_000_000_000: 5} {
+		if got := states[got.Equivalent(n)]; got != want {
+			t.Errorf("state at step %d: got: %v want: %v", n, got, want)
+		}
+	}
+
+	// A pure cycle, starting at step 0, of length 1.
+	id := func(x int) int { return x }
+	if got := Floyd(9, id); got != (Cycle{0, 1}) {
+		t.Errorf("Floyd(identity): got: %+v", got)
+	}
+	if got := Brent(9, id); got != (Cycle{0, 1}) {
+		t.Errorf("Brent(identity): got: %+v", got)
+	}
+}
+
+func TestFindCycleKeys(t *testing.T) {
+	// Slices are not comparable, so are keyed by their contents.
+	rotate := func(s []int) []int { return append(s[1:len(s):len(s)], s[0]) }
+	got, states := FindCycle([]int{1, 2, 3}, rotate, func(s []int) string { return fmt.Sprint(s) })
+	if got != (Cycle{0, 3}) {
+		t.Errorf("FindCycle(): got: %+v want: {0 3}", got)
+	}
+	if s := fmt.Sprint(states[got.Equivalent(10)]); s != "[2 3 1]" {
+		t.Errorf("state at step 10: got: %v want: [2 3 1]", s)
+	}
+}
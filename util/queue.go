@@ -0,0 +1,220 @@
+package util
+
+// Item in a PriorityQueue. Its Value may be changed while it is queued, as long
+// as the queue is then told with Fix.
+type Item[T any] struct {
+	Value T
+	// index of the item in the queue's heap, or -1 once it has left the queue.
+	index int
+}
+
+// Queued is true if the item has not yet been popped or removed from its
+// queue.
+func (it *Item[T]) Queued() bool {
+	return it.index >= 0
+}
+
+// PriorityQueue of values, ordered by a less function so that the least value
+// is popped first. Each pushed value is returned as an Item, which serves as a
+// handle for changing its priority later.
+type PriorityQueue[T any] struct {
+	less  func(a, b T) bool
+	items []*Item[T]
+}
+
+// NewPriorityQueue ordered by less.
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// Len is the number of values in the queue.
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.items)
+}
+
+// Push a value onto the queue, returning its Item.
+func (q *PriorityQueue[T]) Push(v T) *Item[T] {
+	it := &Item[T]{Value: v, index: len(q.items)}
+	q.items = append(q.items, it)
+	q.up(it.index)
+	return it
+}
+
+// Peek at the least value in the queue without removing it. Panics if the
+// queue is empty.
+func (q *PriorityQueue[T]) Peek() T {
+	return q.items[0].Value
+}
+
+// Pop the least value from the queue. Panics if the queue is empty.
+func (q *PriorityQueue[T]) Pop() T {
+	return q.Remove(q.items[0])
+}
+
+// Remove an item from anywhere in the queue, returning its value. Panics if
+// the item is not queued.
+func (q *PriorityQueue[T]) Remove(it *Item[T]) T {
+	i, n := it.index, len(q.items)-1
+	if i != n {
+		q.swap(i, n)
+	}
+	q.items[n] = nil
+	q.items = q.items[:n]
+	if i != n {
+		q.fix(i)
+	}
+	it.index = -1
+	return it.Value
+}
+
+// Fix the position of an item in the queue after its Value has changed. This
+// is how to decrease (or increase) a key. Panics if the item is not queued.
+func (q *PriorityQueue[T]) Fix(it *Item[T]) {
+	q.fix(it.index)
+}
+
+// Update the value of an item, and fix its position in the queue.
+func (q *PriorityQueue[T]) Update(it *Item[T], v T) {
+	it.Value = v
+	q.Fix(it)
+}
+
+func (q *PriorityQueue[T]) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
+
+func (q *PriorityQueue[T]) lessAt(i, j int) bool {
+	return q.less(q.items[i].Value, q.items[j].Value)
+}
+
+func (q *PriorityQueue[T]) fix(i int) {
+	if !q.down(i) {
+		q.up(i)
+	}
+}
+
+func (q *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.lessAt(i, parent) {
+			return
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the item at i down the heap, reporting whether it moved.
+func (q *PriorityQueue[T]) down(i int) bool {
+	start, n := i, len(q.items)
+	for {
+		least := 2*i + 1
+		if least >= n {
+			break
+		}
+		if r := least + 1; r < n && q.lessAt(r, least) {
+			least = r
+		}
+		if !q.lessAt(least, i) {
+			break
+		}
+		q.swap(i, least)
+		i = least
+	}
+	return i > start
+}
+
+// Deque is a double-ended queue backed by a ring buffer. The zero value is an
+// empty Deque ready to use.
+type Deque[T any] struct {
+	buf []T
+	// head is the index in buf of the front value, and n the number of values.
+	head, n int
+}
+
+// Len is the number of values in the deque.
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+func (d *Deque[T]) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+	buf := make([]T, max(8, 2*len(d.buf)))
+	// Unwrap the ring so that the front is at the start of the new buffer.
+	copy(buf, d.buf[d.head:])
+	copy(buf[len(d.buf)-d.head:], d.buf[:d.head])
+	d.buf, d.head = buf, 0
+}
+
+// slot is the index in buf of the i-th value from the front.
+func (d *Deque[T]) slot(i int) int {
+	return (d.head + i) % len(d.buf)
+}
+
+// PushBack adds a value to the back of the deque.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.slot(d.n)] = v
+	d.n++
+}
+
+// PushFront adds a value to the front of the deque.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.slot(len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront removes and returns the value at the front of the deque. Panics if
+// the deque is empty.
+func (d *Deque[T]) PopFront() T {
+	if d.n == 0 {
+		panic("util.Deque: PopFront of empty deque")
+	}
+	var zero T
+	v := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.slot(1)
+	d.n--
+	return v
+}
+
+// PopBack removes and returns the value at the back of the deque. Panics if
+// the deque is empty.
+func (d *Deque[T]) PopBack() T {
+	if d.n == 0 {
+		panic("util.Deque: PopBack of empty deque")
+	}
+	var zero T
+	i := d.slot(d.n - 1)
+	v := d.buf[i]
+	d.buf[i] = zero
+	d.n--
+	return v
+}
+
+// Front returns the value at the front of the deque. Panics if the deque is
+// empty.
+func (d *Deque[T]) Front() T {
+	return d.At(0)
+}
+
+// Back returns the value at the back of the deque. Panics if the deque is
+// empty.
+func (d *Deque[T]) Back() T {
+	return d.At(d.n - 1)
+}
+
+// At returns the i-th value from the front of the deque. Panics if i is out of
+// range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.n {
+		panic("util.Deque: index out of range")
+	}
+	return d.buf[d.slot(i)]
+}
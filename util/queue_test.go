@@ -0,0 +1,154 @@
+package util
+
+import (
+	"container/heap"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func less(a, b int) bool {
+	return a < b
+}
+
+func TestPriorityQueue(t *testing.T) {
+	r := rand.New(rand.NewSource(2023))
+	q := NewPriorityQueue(less)
+	var want []int
+	for i := 0; i < 200; i++ {
+		v := r.Intn(100)
+		q.Push(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+	if got := q.Peek(); got != want[0] {
+		t.Errorf("Peek(): got: %v want: %v", got, want[0])
+	}
+	var got []int
+	for q.Len() > 0 {
+		got = append(got, q.Pop())
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Pop() order: mismatch (-got,+want):\n%v", diff)
+	}
+}
+
+func TestPriorityQueueHandles(t *testing.T) {
+	q := NewPriorityQueue(less)
+	items := make(map[int]*Item[int])
+	for _, v := range []int{50, 40, 30, 20, 10} {
+		items[v] = q.Push(v)
+	}
+	// Decrease a key below the current minimum, increase another past the
+	// maximum, and remove one outright.
+	q.Update(items[40], 5)
+	items[20].Value = 60
+	q.Fix(items[20])
+	if got := q.Remove(items[30]); got != 30 {
+		t.Errorf("Remove(): got: %v want: 30", got)
+	}
+	if items[30].Queued() {
+		t.Errorf("removed item is still queued")
+	}
+	var got []int
+	for q.Len() > 0 {
+		got = append(got, q.Pop())
+	}
+	if diff := cmp.Diff(got, []int{5, 10, 50, 60}); diff != "" {
+		t.Errorf("Pop() order: mismatch (-got,+want):\n%v", diff)
+	}
+	for v, it := range items {
+		if it.Queued() {
+			t.Errorf("item %d is still queued after draining", v)
+		}
+	}
+}
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	// Interleave pushes at both ends, past the initial capacity, so that the
+	// ring wraps and grows.
+	for i := 0; i < 20; i++ {
+		d.PushBack(i)
+		d.PushFront(-i - 1)
+	}
+	if d.Len() != 40 {
+		t.Fatalf("Len(): got: %v want: 40", d.Len())
+	}
+	if d.Front() != -20 || d.Back() != 19 || d.At(20) != 0 {
+		t.Errorf("Front(), Back(), At(20): got: %v %v %v want: -20 19 0", d.Front(), d.Back(), d.At(20))
+	}
+	var got []int
+	for d.Len() > 2 {
+		got = append(got, d.PopFront(), d.PopBack())
+	}
+	if diff := cmp.Diff(got[:4], []int{-20, 19, -19, 18}); diff != "" {
+		t.Errorf("Pop order: mismatch (-got,+want):\n%v", diff)
+	}
+	d.PushBack(100)
+	if diff := cmp.Diff([]int{d.PopFront(), d.PopFront(), d.PopFront()}, []int{-1, 0, 100}); diff != "" {
+		t.Errorf("PopFront() after wrapping: mismatch (-got,+want):\n%v", diff)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("PopFront() of empty Deque did not panic")
+		}
+	}()
+	d.PopFront()
+}
+
+// intHeap implements heap.Interface, for comparison with PriorityQueue.
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	v := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return v
+}
+
+const benchmarkQueueSize = 1000
+
+func BenchmarkPriorityQueue(b *testing.B) {
+	r := rand.New(rand.NewSource(2023))
+	for i := 0; i < b.N; i++ {
+		q := NewPriorityQueue(less)
+		for j := 0; j < benchmarkQueueSize; j++ {
+			q.Push(r.Int())
+		}
+		for q.Len() > 0 {
+			q.Pop()
+		}
+	}
+}
+
+func BenchmarkContainerHeap(b *testing.B) {
+	r := rand.New(rand.NewSource(2023))
+	for i := 0; i < b.N; i++ {
+		h := &intHeap{}
+		for j := 0; j < benchmarkQueueSize; j++ {
+			heap.Push(h, r.Int())
+		}
+		for h.Len() > 0 {
+			heap.Pop(h)
+		}
+	}
+}
+
+func BenchmarkDeque(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		var d Deque[int]
+		for j := 0; j < benchmarkQueueSize; j++ {
+			d.PushBack(j)
+		}
+		for d.Len() > 0 {
+			d.PopFront()
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package util
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Set of distinct values. The zero value is an empty set ready to use.
+type Set[T comparable] struct {
+	values map[T]struct{}
+}
+
+// NewSet creates a new set populated with all distinct values.
+func NewSet[T comparable](values []T) *Set[T] {
+	ret := &Set[T]{
+		values: make(map[T]struct{}, len(values)),
+	}
+	ret.Add(values...)
+	return ret
+}
+
+// Add values to the set.
+func (s *Set[T]) Add(values ...T) {
+	if s.values == nil {
+		s.values = make(map[T]struct{}, len(values))
+	}
+	for _, v := range values {
+		s.values[v] = struct{}{}
+	}
+}
+
+// Remove values from the set, if present.
+func (s *Set[T]) Remove(values ...T) {
+	for _, v := range values {
+		delete(s.values, v)
+	}
+}
+
+// Contains is true if v is in the set.
+func (s *Set[T]) Contains(v T) bool {
+	_, ok := s.values[v]
+	return ok
+}
+
+// Len is the number of values in the set.
+func (s *Set[T]) Len() int {
+	return len(s.values)
+}
+
+// Each calls fn for each value in the set, in no particular order, until fn
+// returns false.
+func (s *Set[T]) Each(fn func(T) bool) {
+	for v := range s.values {
+		if !fn(v) {
+			return
+		}
+	}
+}
+
+// Values from the set as a slice, in no particular order. See Sorted and
+// SortedFunc for ordered results.
+func (s *Set[T]) Values() (ret []T) {
+	for v := range s.values {
+		ret = append(ret, v)
+	}
+	return
+}
+
+// SortedFunc returns the values from the set as a slice, sorted by the
+// comparison function.
+func (s *Set[T]) SortedFunc(cmp func(l, r T) int) []T {
+	ret := s.Values()
+	slices.SortFunc(ret, cmp)
+	return ret
+}
+
+// Sorted values from a set of ordered values, in ascending order according to
+// cmp.Compare.
+func Sorted[T cmp.Ordered](s *Set[T]) []T {
+	return s.SortedFunc(cmp.Compare[T])
+}
+
+// filter returns a new set of the values in s for which keep is true.
+func (s *Set[T]) filter(keep func(T) bool) *Set[T] {
+	ret := &Set[T]{values: make(map[T]struct{})}
+	for v := range s.values {
+		if keep(v) {
+			ret.values[v] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// Intersection of two sets is the values which appear in each.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	if other.Len() < s.Len() {
+		return other.filter(s.Contains)
+	}
+	return s.filter(other.Contains)
+}
+
+// Union of two sets is the values which appear in either.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	ret := s.filter(func(T) bool { return true })
+	for v := range other.values {
+		ret.values[v] = struct{}{}
+	}
+	return ret
+}
+
+// Difference of two sets is the values in s which do not appear in other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	return s.filter(func(v T) bool { return !other.Contains(v) })
+}
+
+// SymmetricDifference of two sets is the values which appear in exactly one
+// of them.
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	ret := s.Difference(other)
+	for v := range other.values {
+		if !s.Contains(v) {
+			ret.values[v] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// IsSubset is true if every value in s also appears in other.
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if s.Len() > other.Len() {
+		return false
+	}
+	for v := range s.values {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
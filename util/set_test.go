@@ -0,0 +1,128 @@
+package util
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSetZeroValue(t *testing.T) {
+	var s Set[string]
+	if s.Contains("a") || s.Len() != 0 {
+		t.Errorf("zero Set is not empty")
+	}
+	s.Remove("a")
+	s.Add("a", "b", "a")
+	if !s.Contains("a") || !s.Contains("b") || s.Len() != 2 {
+		t.Errorf("Add(): got: %v want: [a b]", Sorted(&s))
+	}
+	s.Remove("a")
+	if diff := cmp.Diff(Sorted(&s), []string{"b"}); diff != "" {
+		t.Errorf("Remove(): mismatch (-got,+want):\n%v", diff)
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	type test struct {
+		op   func(l, r *Set[int]) *Set[int]
+		l, r []int
+		want []int
+	}
+
+	for tn, tc := range map[string]test{
+		"intersection":                {(*Set[int]).Intersection, []int{1, 2, 3}, []int{2, 3, 4}, []int{2, 3}},
+		"intersection empty":          {(*Set[int]).Intersection, []int{1}, nil, nil},
+		"union":                       {(*Set[int]).Union, []int{1, 2, 3}, []int{2, 3, 4}, []int{1, 2, 3, 4}},
+		"union empty":                 {(*Set[int]).Union, nil, nil, nil},
+		"difference":                  {(*Set[int]).Difference, []int{1, 2, 3}, []int{2, 3, 4}, []int{1}},
+		"difference reversed":         {(*Set[int]).Difference, []int{2, 3, 4}, []int{1, 2, 3}, []int{4}},
+		"symmetric difference":        {(*Set[int]).SymmetricDifference, []int{1, 2, 3}, []int{2, 3, 4}, []int{1, 4}},
+		"symmetric difference itself": {(*Set[int]).SymmetricDifference, []int{1, 2}, []int{1, 2}, nil},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				l, r := NewSet(tc.l), NewSet(tc.r)
+				got := Sorted(tc.op(l, r))
+				if diff := cmp.Diff(got, tc.want); diff != "" {
+					t.Errorf("mismatch (-got,+want):\n%v", diff)
+				}
+				// Operands are left untouched.
+				if diff := cmp.Diff(l.Len(), NewSet(tc.l).Len()); diff != "" {
+					t.Errorf("left operand modified (-got,+want):\n%v", diff)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestSetIsSubset(t *testing.T) {
+	type test struct {
+		l, r []string
+		want bool
+	}
+
+	for tn, tc := range map[string]test{
+		"empty":         {nil, nil, true},
+		"empty of any":  {nil, []string{"a"}, true},
+		"proper subset": {[]string{"a"}, []string{"a", "b"}, true},
+		"equal":         {[]string{"a", "b"}, []string{"b", "a"}, true},
+		"superset":      {[]string{"a", "b"}, []string{"a"}, false},
+		"disjoint":      {[]string{"a"}, []string{"b"}, false},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				if got := NewSet(tc.l).IsSubset(NewSet(tc.r)); got != tc.want {
+					t.Errorf("IsSubset(): mismatch: got: %v want: %v", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	if diff := cmp.Diff(Sorted(NewSet([]float64{0.5, -0.25, 0.25})), []float64{-0.25, 0.25, 0.5}); diff != "" {
+		t.Errorf("Sorted(): floats mismatch (-got,+want):\n%v", diff)
+	}
+	big := []uint64{math.MaxUint64, 0, math.MaxUint64 / 2}
+	if diff := cmp.Diff(Sorted(NewSet(big)), []uint64{0, math.MaxUint64 / 2, math.MaxUint64}); diff != "" {
+		t.Errorf("Sorted(): uint64s mismatch (-got,+want):\n%v", diff)
+	}
+	ints := []int{math.MaxInt, math.MinInt, 0}
+	if diff := cmp.Diff(Sorted(NewSet(ints)), []int{math.MinInt, 0, math.MaxInt}); diff != "" {
+		t.Errorf("Sorted(): ints mismatch (-got,+want):\n%v", diff)
+	}
+}
+
+func TestSetSortedFunc(t *testing.T) {
+	type point struct{ X, Y int }
+	s := NewSet([]point{{1, 2}, {0, 5}, {1, 2}, {0, 1}})
+	got := s.SortedFunc(func(l, r point) int {
+		if l.X != r.X {
+			return l.X - r.X
+		}
+		return l.Y - r.Y
+	})
+	if diff := cmp.Diff(got, []point{{0, 1}, {0, 5}, {1, 2}}); diff != "" {
+		t.Errorf("SortedFunc(): mismatch (-got,+want):\n%v", diff)
+	}
+}
+
+func TestSetEach(t *testing.T) {
+	s := NewSet([]int{1, 2, 3, 4})
+	var sum, calls int
+	s.Each(func(v int) bool {
+		sum += v
+		return true
+	})
+	if sum != 10 {
+		t.Errorf("Each(): visited sum: got: %d want: 10", sum)
+	}
+	s.Each(func(int) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("Each(): did not stop early: got %d calls", calls)
+	}
+}
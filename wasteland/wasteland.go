@@ -0,0 +1,361 @@
+// Package wasteland models the desert network of nodes walked in stars fifteen
+// and sixteen.
+// See: https://adventofcode.com/2023/day/8
+package wasteland
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cfunkhouser/aoc2023/util"
+)
+
+// Network of nodes, each leading left and right to another, along with the
+// instructions for walking it.
+type Network struct {
+	// Instructions of L and R, which repeat once exhausted.
+	Instructions string
+	// Nodes by name, each with its left and right neighbours.
+	Nodes map[string][2]string
+}
+
+var (
+	// ErrInvalidNetwork is returned when a network cannot be parsed.
+	ErrInvalidNetwork = errors.New("invalid network")
+	// ErrUnreachable is returned when a walk can never reach its goal.
+	ErrUnreachable = errors.New("goal is unreachable")
+)
+
+// state of a walk: the current node, and the index of the next instruction.
+type state struct {
+	node string
+	next int
+}
+
+// step the walk forward by one instruction.
+func (n *Network) step(s state) state {
+	side := 0
+	if n.Instructions[s.next] == 'R' {
+		side = 1
+	}
+	return state{n.Nodes[s.node][side], (s.next + 1) % len(n.Instructions)}
+}
+
+// Walk from a node, following the instructions, until reaching a node for
+// which goal is true, returning the number of steps taken. Returns
+// ErrUnreachable if the walk begins to repeat itself first.
+func (n *Network) Walk(from string, goal func(string) bool) (int, error) {
+	if _, ok := n.Nodes[from]; !ok {
+		return 0, fmt.Errorf("%w: unknown node %q", ErrInvalidNetwork, from)
+	}
+	seen := make(map[state]bool)
+	s := state{node: from}
+	for steps := 0; ; steps++ {
+		if goal(s.node) {
+			return steps, nil
+		}
+		if seen[s] {
+			return 0, fmt.Errorf("%w: from %s", ErrUnreachable, from)
+		}
+		seen[s] = true
+		s = n.step(s)
+	}
+}
+
+// Ghost walking the network from Start. The walk eventually repeats, as there
+// are finitely many states.
+type Ghost struct {
+	Start string
+	// Cycle in the ghost's walk.
+	Cycle util.Cycle
+	// Hits are the steps, before Cycle.Start+Cycle.Length, at which the ghost
+	// is on a goal node. Hits from Cycle.Start on recur every Cycle.Length
+	// steps.
+	Hits []int
+}
+
+// transient hits, which happen once before the cycle begins, and cyclic hits,
+// which recur.
+func (g *Ghost) split() (transient, cyclic []int) {
+	i := sort.SearchInts(g.Hits, g.Cycle.Start)
+	return g.Hits[:i], g.Hits[i:]
+}
+
+// Method by which the ghosts' walks were combined.
+type Method int
+
+const (
+	// Direct means the ghosts were found on goals together before every one
+	// of them had entered its cycle.
+	Direct Method = iota
+	// LCM means every ghost reached a goal exactly at the end of each cycle,
+	// and at no other point in it, so the answer is the least common multiple
+	// of their cycle lengths.
+	LCM
+	// CRT means the goal hits in each ghost's cycle were combined using the
+	// Chinese Remainder Theorem, without assuming anything of their structure.
+	CRT
+)
+
+var methodNames = [...]string{
+	Direct: "direct",
+	LCM:    "lcm",
+	CRT:    "crt",
+}
+
+func (m Method) String() string {
+	if m < 0 || int(m) >= len(methodNames) {
+		return fmt.Sprintf("Method(%d)", int(m))
+	}
+	return methodNames[m]
+}
+
+// Analysis of ghosts walking the network together.
+type Analysis struct {
+	Ghosts []*Ghost
+	// Steps after which every ghost is on a goal node at once.
+	Steps int
+	// Method by which Steps was found.
+	Method Method
+}
+
+// ErrNeverAligned is returned when the ghosts are never all on goal nodes at
+// once.
+var ErrNeverAligned = errors.New("ghosts never reach goals together")
+
+// Haunt the network with a ghost starting at every node for which isStart is
+// true, all stepping together, and find the number of steps after which every
+// ghost is on a node for which isGoal is true. Each ghost's cycle is detected
+// and its goal hits recorded. The hits are then combined with the least common
+// multiple of the cycle lengths if they have the structure that permits it,
+// and the Chinese Remainder Theorem otherwise.
+func (n *Network) Haunt(isStart, isGoal func(string) bool) (*Analysis, error) {
+	a := &Analysis{}
+	var starts []string
+	for name := range n.Nodes {
+		if isStart(name) {
+			starts = append(starts, name)
+		}
+	}
+	if len(starts) == 0 {
+		return nil, fmt.Errorf("%w: no starting nodes", ErrInvalidNetwork)
+	}
+	sort.Strings(starts)
+
+	// histories of each ghost's states, up to the end of its first cycle.
+	histories := make([][]state, len(starts))
+	var settled int
+	for i, start := range starts {
+		c, states := util.FindCycle(state{node: start}, n.step, func(s state) state { return s })
+		g := &Ghost{Start: start, Cycle: c}
+		for step, s := range states {
+			if isGoal(s.node) {
+				g.Hits = append(g.Hits, step)
+			}
+		}
+		if len(g.Hits) == 0 {
+			return nil, fmt.Errorf("%w: from %s", ErrUnreachable, start)
+		}
+		a.Ghosts = append(a.Ghosts, g)
+		histories[i] = states
+		settled = max(settled, c.Start)
+	}
+
+	// Until every ghost is in its cycle, check each step directly.
+	for step := 0; step < settled; step++ {
+		if a.allOnGoals(step, histories, isGoal) {
+			a.Steps, a.Method = step, Direct
+			return a, nil
+		}
+	}
+
+	if steps, ok := a.lcm(settled); ok {
+		a.Steps, a.Method = steps, LCM
+		return a, nil
+	}
+	steps, err := a.crt(settled)
+	if err != nil {
+		return nil, err
+	}
+	a.Steps, a.Method = steps, CRT
+	return a, nil
+}
+
+func (a *Analysis) allOnGoals(step int, histories [][]state, isGoal func(string) bool) bool {
+	for i, g := range a.Ghosts {
+		if !isGoal(histories[i][g.Cycle.Equivalent(step)].node) {
+			return false
+		}
+	}
+	return true
+}
+
+// lcm of the cycle lengths, if each ghost's only goal hit within its cycle
+// is at a multiple of its length. The result is the least multiple of it which
+// is at least settled.
+func (a *Analysis) lcm(settled int) (int, bool) {
+	lengths := make([]int, len(a.Ghosts))
+	for i, g := range a.Ghosts {
+		_, cyclic := g.split()
+		if len(cyclic) != 1 || cyclic[0]%g.Cycle.Length != 0 {
+			return 0, false
+		}
+		lengths[i] = g.Cycle.Length
+	}
+	// Check that the LCM fits before trusting it.
+	l := 1
+	for _, n := range lengths {
+		var ok bool
+		if l, ok = util.MulChecked(l/util.GCD(l, n), n); !ok {
+			return 0, false
+		}
+	}
+	return util.MulChecked((settled+l-1)/l, l)
+}
+
+// crt combines every choice of cyclic goal hit across the ghosts, returning the
+// least step of at least settled at which all are on goals.
+func (a *Analysis) crt(settled int) (int, error) {
+	// Each candidate is a residue and modulus: steps congruent to the residue
+	// put every ghost combined so far on a goal.
+	type congruence struct{ residue, modulus int }
+	candidates := []congruence{{0, 1}}
+	for _, g := range a.Ghosts {
+		_, cyclic := g.split()
+		seen := make(map[congruence]bool)
+		var next []congruence
+		for _, c := range candidates {
+			for _, h := range cyclic {
+				x, m, err := util.CRT([]int{c.residue, h}, []int{c.modulus, g.Cycle.Length})
+				if errors.Is(err, util.ErrNoSolution) {
+					continue
+				}
+				if err != nil {
+					return 0, fmt.Errorf("ghost from %s: %w", g.Start, err)
+				}
+				if nc := (congruence{x, m}); !seen[nc] {
+					seen[nc] = true
+					next = append(next, nc)
+				}
+			}
+		}
+		if len(next) == 0 {
+			return 0, ErrNeverAligned
+		}
+		candidates = next
+	}
+	best := -1
+	for _, c := range candidates {
+		// The least step congruent to the residue which is at least settled.
+		steps := c.residue
+		if steps < settled {
+			steps += (settled - steps + c.modulus - 1) / c.modulus * c.modulus
+		}
+		if best < 0 || steps < best {
+			best = steps
+		}
+	}
+	return best, nil
+}
+
+// WriteReport writes an account of each ghost's cycle and goal hits, and of
+// the method by which they were combined, to w.
+func (a *Analysis) WriteReport(w io.Writer) error {
+	var buf strings.Builder
+	for _, g := range a.Ghosts {
+		transient, cyclic := g.split()
+		fmt.Fprintf(&buf, "%s: cycle of length %d from step %d", g.Start, g.Cycle.Length, g.Cycle.Start)
+		if len(transient) > 0 {
+			fmt.Fprintf(&buf, ", goals once at %s", joinInts(transient))
+		}
+		if len(cyclic) > 0 {
+			fmt.Fprintf(&buf, ", goals at %s and every %d steps after", joinInts(cyclic), g.Cycle.Length)
+		}
+		fmt.Fprintln(&buf)
+	}
+	switch a.Method {
+	case Direct:
+		fmt.Fprintf(&buf, "Found directly, before every ghost entered its cycle: %d steps.\n", a.Steps)
+	case LCM:
+		fmt.Fprintf(&buf, "Each ghost reaches a goal only at the end of its cycle, so using the LCM of the cycle lengths: %d steps.\n", a.Steps)
+	case CRT:
+		fmt.Fprintf(&buf, "Cycles lack the structure for an LCM, so combining goal hits with the CRT: %d steps.\n", a.Steps)
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+func joinInts(ns []int) string {
+	s := make([]string, len(ns))
+	for i, n := range ns {
+		s[i] = fmt.Sprint(n)
+	}
+	return strings.Join(s, ", ")
+}
+
+// parseNode parses a line of the form "AAA = (BBB, CCC)".
+func parseNode(s string) (name string, next [2]string, err error) {
+	name, rest, ok := strings.Cut(s, "=")
+	rest = strings.TrimSpace(rest)
+	if !ok || !strings.HasPrefix(rest, "(") || !strings.HasSuffix(rest, ")") {
+		return "", next, fmt.Errorf("expected NAME = (LEFT, RIGHT), got %q", s)
+	}
+	left, right, ok := strings.Cut(rest[1:len(rest)-1], ",")
+	if !ok {
+		return "", next, fmt.Errorf("expected NAME = (LEFT, RIGHT), got %q", s)
+	}
+	name = strings.TrimSpace(name)
+	next = [2]string{strings.TrimSpace(left), strings.TrimSpace(right)}
+	if name == "" || next[0] == "" || next[1] == "" {
+		return "", next, fmt.Errorf("empty node name in %q", s)
+	}
+	return name, next, nil
+}
+
+// FromDocument parses a network: a line of instructions, followed by a node
+// per line. Every node referred to must be defined.
+func FromDocument(doc io.Reader) (*Network, error) {
+	n := &Network{Nodes: make(map[string][2]string)}
+	s := bufio.NewScanner(doc)
+	for line := 1; s.Scan(); line++ {
+		l := strings.TrimSpace(s.Text())
+		var err error
+		switch {
+		case line == 1:
+			if l == "" || strings.Trim(l, "LR") != "" {
+				err = fmt.Errorf("instructions must be L or R, got %q", l)
+			}
+			n.Instructions = l
+		case l == "":
+			continue
+		default:
+			name, next, perr := parseNode(l)
+			if _, dup := n.Nodes[name]; perr == nil && dup {
+				perr = fmt.Errorf("duplicate node %s", name)
+			}
+			err = perr
+			n.Nodes[name] = next
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w: %w", line, ErrInvalidNetwork, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if n.Instructions == "" {
+		return nil, fmt.Errorf("%w: missing instructions", ErrInvalidNetwork)
+	}
+	for name, next := range n.Nodes {
+		for _, o := range next {
+			if _, ok := n.Nodes[o]; !ok {
+				return nil, fmt.Errorf("%w: node %s leads to undefined node %s", ErrInvalidNetwork, name, o)
+			}
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,233 @@
+package wasteland
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func endsWith(suffix string) func(string) bool {
+	return func(s string) bool { return strings.HasSuffix(s, suffix) }
+}
+
+func network(t *testing.T, doc string) *Network {
+	t.Helper()
+	n, err := FromDocument(bytes.NewBufferString(doc))
+	if err != nil {
+		t.Fatalf("FromDocument(): unexpected error: %v", err)
+	}
+	return n
+}
+
+func TestWalk(t *testing.T) {
+	type test struct {
+		doc  string
+		want int
+	}
+
+	for tn, tc := range map[string]test{
+		"first example": {`RL
+
+AAA = (BBB, CCC)
+BBB = (DDD, EEE)
+CCC = (ZZZ, GGG)
+DDD = (DDD, DDD)
+EEE = (EEE, EEE)
+GGG = (GGG, GGG)
+ZZZ = (ZZZ, ZZZ)`, 2},
+		"second example": {`LLR
+
+AAA = (BBB, BBB)
+BBB = (AAA, ZZZ)
+ZZZ = (ZZZ, ZZZ)`, 6},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				got, err := network(t, tc.doc).Walk("AAA", endsWith("ZZZ"))
+				if err != nil {
+					t.Fatalf("Walk(): unexpected error: %v", err)
+				}
+				if got != tc.want {
+					t.Errorf("Walk(): got: %d want: %d", got, tc.want)
+				}
+			})
+		}(t, tn, &tc)
+	}
+
+	n := network(t, "L\n\nAAA = (BBB, BBB)\nBBB = (AAA, AAA)\nZZZ = (ZZZ, ZZZ)")
+	if _, err := n.Walk("AAA", endsWith("ZZZ")); !errors.Is(err, ErrUnreachable) {
+		t.Errorf("Walk() in a loop: got error: %v want: %v", err, ErrUnreachable)
+	}
+	if _, err := n.Walk("QQQ", endsWith("ZZZ")); !errors.Is(err, ErrInvalidNetwork) {
+		t.Errorf("Walk() from unknown node: got error: %v want: %v", err, ErrInvalidNetwork)
+	}
+}
+
+// simulate every ghost step by step, for comparison with Haunt.
+func simulate(n *Network, limit int) (int, bool) {
+	var ghosts []state
+	for name := range n.Nodes {
+		if strings.HasSuffix(name, "A") {
+			ghosts = append(ghosts, state{node: name})
+		}
+	}
+	for step := 0; step < limit; step++ {
+		all := true
+		for i, g := range ghosts {
+			all = all && strings.HasSuffix(g.node, "Z")
+			ghosts[i] = n.step(g)
+		}
+		if all {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func TestHaunt(t *testing.T) {
+	type test struct {
+		doc    string
+		want   int
+		method Method
+	}
+
+	for tn, tc := range map[string]test{
+		// The second ghost reaches a goal twice in each cycle, so the LCM
+		// shortcut does not apply.
+		"example from problem": {`LR
+
+11A = (11B, XXX)
+11B = (XXX, 11Z)
+11Z = (11B, XXX)
+22A = (22B, XXX)
+22B = (22C, 22C)
+22C = (22Z, 22Z)
+22Z = (22B, 22B)
+XXX = (XXX, XXX)`, 6, CRT},
+		"usual structure": {`LR
+
+11A = (11B, XXX)
+11B = (XXX, 11Z)
+11Z = (11B, XXX)
+33A = (33B, 33B)
+33B = (33C, 33C)
+33C = (33D, 33D)
+33D = (33Z, 33Z)
+33Z = (33B, 33B)
+XXX = (XXX, XXX)`, 4, LCM},
+		// Goals at steps 2 mod 3 and 1 mod 4.
+		"offset cycles": {`L
+
+1A = (1B, 1B)
+1B = (1Z, 1Z)
+1Z = (1C, 1C)
+1C = (1B, 1B)
+2A = (2Z, 2Z)
+2Z = (2B, 2B)
+2B = (2C, 2C)
+2C = (2A, 2A)`, 5, CRT},
+		"before cycles": {`L
+
+1A = (1Z, 1Z)
+1Z = (1B, 1B)
+1B = (1B, 1B)
+2A = (2Z, 2Z)
+2Z = (2Z, 2Z)`, 1, Direct},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				n := network(t, tc.doc)
+				a, err := n.Haunt(endsWith("A"), endsWith("Z"))
+				if err != nil {
+					t.Fatalf("Haunt(): unexpected error: %v", err)
+				}
+				if a.Steps != tc.want || a.Method != tc.method {
+					t.Errorf("Haunt(): got: %d by %v want: %d by %v", a.Steps, a.Method, tc.want, tc.method)
+				}
+				if want, ok := simulate(n, 1000); !ok || want != a.Steps {
+					t.Errorf("Haunt(): got: %d, but simulation found %d, %v", a.Steps, want, ok)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestHauntErrors(t *testing.T) {
+	type test struct {
+		doc string
+		err error
+	}
+
+	for tn, tc := range map[string]test{
+		// Goals at odd steps, and at steps 2 mod 4.
+		"never aligned": {`L
+
+1A = (1Z, 1Z)
+1Z = (1A, 1A)
+2A = (2B, 2B)
+2B = (2Z, 2Z)
+2Z = (2C, 2C)
+2C = (2A, 2A)`, ErrNeverAligned},
+		"unreachable": {`L
+
+1A = (1Z, 1Z)
+1Z = (1A, 1A)
+2A = (2A, 2A)`, ErrUnreachable},
+		"no starts": {"L\n\n1Z = (1Z, 1Z)", ErrInvalidNetwork},
+	} {
+		func(t *testing.T, tn string, tc *test) {
+			t.Run(tn, func(t *testing.T) {
+				if _, err := network(t, tc.doc).Haunt(endsWith("A"), endsWith("Z")); !errors.Is(err, tc.err) {
+					t.Errorf("Haunt(): got error: %v want: %v", err, tc.err)
+				}
+			})
+		}(t, tn, &tc)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	n := network(t, `L
+
+1A = (1B, 1B)
+1B = (1Z, 1Z)
+1Z = (1C, 1C)
+1C = (1B, 1B)
+2A = (2Z, 2Z)
+2Z = (2B, 2B)
+2B = (2C, 2C)
+2C = (2A, 2A)`)
+	a, err := n.Haunt(endsWith("A"), endsWith("Z"))
+	if err != nil {
+		t.Fatalf("Haunt(): unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := a.WriteReport(&buf); err != nil {
+		t.Fatalf("WriteReport(): unexpected error: %v", err)
+	}
+	want := `1A: cycle of length 3 from step 1, goals at 2 and every 3 steps after
+2A: cycle of length 4 from step 0, goals at 1 and every 4 steps after
+Cycles lack the structure for an LCM, so combining goal hits with the CRT: 5 steps.
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteReport(): got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFromDocumentErrors(t *testing.T) {
+	for tn, doc := range map[string]string{
+		"empty":          "",
+		"bad direction":  "LX\n\nAAA = (AAA, AAA)",
+		"malformed node": "L\n\nAAA = AAA, AAA",
+		"undefined node": "L\n\nAAA = (BBB, AAA)",
+		"duplicate node": "L\n\nAAA = (AAA, AAA)\nAAA = (AAA, AAA)",
+	} {
+		func(t *testing.T, tn, doc string) {
+			t.Run(tn, func(t *testing.T) {
+				if _, err := FromDocument(bytes.NewBufferString(doc)); !errors.Is(err, ErrInvalidNetwork) {
+					t.Errorf("FromDocument(): got error: %v want: %v", err, ErrInvalidNetwork)
+				}
+			})
+		}(t, tn, doc)
+	}
+}